@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 )
@@ -62,6 +63,10 @@ var supportedOperators = []FilterOperator{
 	FilterOperatorMatchPhrase,
 }
 
+func SupportedOperators() []FilterOperator {
+	return slices.Clone(supportedOperators)
+}
+
 type ValidationError struct {
 	Path    string
 	Error   string
@@ -87,6 +92,19 @@ func (c *FilterCondition) IsNegative() bool {
 	}, c.Operator)
 }
 
+// ValueList returns the items of a slice or array value (e.g. of in and not-in) as []any, other values are wrapped
+func (c *FilterCondition) ValueList() []any {
+	reflectedValue := reflect.ValueOf(c.Value)
+	if reflectedValue.Kind() != reflect.Slice && reflectedValue.Kind() != reflect.Array {
+		return []any{c.Value}
+	}
+	values := make([]any, 0, reflectedValue.Len())
+	for index := 0; index < reflectedValue.Len(); index++ {
+		values = append(values, reflectedValue.Index(index).Interface())
+	}
+	return values
+}
+
 func (c *FilterCondition) UnmarshalJSON(data []byte) error {
 	var condition struct {
 		Field    string
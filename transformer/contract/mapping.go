@@ -0,0 +1,61 @@
+package contract
+
+import (
+	"fmt"
+)
+
+const ValidationErrorUnmappedField = "unmapped field"
+
+// FieldMapping maps the public fields (used in the filters sent by the clients) to the fields of the backend;
+// a public field mapped to several backend fields matches if any of them matches
+type FieldMapping map[string][]string
+
+func (m FieldMapping) mapCondition(condition FilterCondition, path string, target *FilterConditions, validationErrors *[]ValidationError) {
+	fields := m[condition.Field]
+	if len(fields) == 0 {
+		*validationErrors = append(*validationErrors, ValidationError{
+			Path:    fmt.Sprintf("%s.field", path),
+			Error:   ValidationErrorUnmappedField,
+			Field:   "field",
+			Payload: condition.Field,
+		})
+		return
+	}
+	if len(fields) == 1 {
+		condition.Field = fields[0]
+		target.Conditions = append(target.Conditions, condition)
+		return
+	}
+	// the negative operators require all the backend fields to match (e.g. `name not-contains x` means that none of them
+	// contains x, `name nil` that all of them are nil)
+	expanded := Filters{Logic: FilterLogicOr}
+	if condition.IsNegative() {
+		expanded.Logic = FilterLogicAnd
+	}
+	for _, field := range fields {
+		condition.Field = field
+		expanded.Conditions.Conditions = append(expanded.Conditions.Conditions, condition)
+	}
+	target.Filters = append(target.Filters, expanded)
+}
+
+func (m FieldMapping) mapFilters(filters Filters, path string, validationErrors *[]ValidationError) Filters {
+	mapped := Filters{Logic: filters.Logic}
+	for index, filter := range filters.Conditions.Filters {
+		mapped.Conditions.Filters = append(mapped.Conditions.Filters, m.mapFilters(filter, fmt.Sprintf("%s.conditions.%d", path, index), validationErrors))
+	}
+	for index, condition := range filters.Conditions.Conditions {
+		m.mapCondition(condition, fmt.Sprintf("%s.conditions.%d", path, index), &mapped.Conditions, validationErrors)
+	}
+	return mapped
+}
+
+// MapFields returns a copy of the filters using the backend fields, unmapped fields are reported using the same paths as Validate
+func (f *Filters) MapFields(mapping FieldMapping) (Filters, []ValidationError) {
+	var validationErrors []ValidationError
+	mapped := mapping.mapFilters(*f, "root", &validationErrors)
+	if len(validationErrors) > 0 {
+		return Filters{}, validationErrors
+	}
+	return mapped, nil
+}
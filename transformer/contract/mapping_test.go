@@ -0,0 +1,207 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilters_MapFields(t *testing.T) {
+	mapping := FieldMapping{
+		"createdAt": {"orders.created_at"},
+		"status":    {"orders.status"},
+		"name":      {"customers.first_name", "customers.last_name"},
+	}
+	tests := []struct {
+		name                 string
+		filters              Filters
+		want                 Filters
+		wantValidationErrors []ValidationError
+	}{
+		{
+			name:                 "empty filters",
+			filters:              Filters{},
+			want:                 Filters{},
+			wantValidationErrors: nil,
+		},
+		{
+			name: "renamed fields",
+			filters: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "createdAt", Operator: FilterOperatorGreaterThanOrEqual, Value: "2024-01-01"},
+						{Field: "status", Operator: FilterOperatorIn, Value: []string{"new", "paid"}},
+					},
+				},
+			},
+			want: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "orders.created_at", Operator: FilterOperatorGreaterThanOrEqual, Value: "2024-01-01"},
+						{Field: "orders.status", Operator: FilterOperatorIn, Value: []string{"new", "paid"}},
+					},
+				},
+			},
+			wantValidationErrors: nil,
+		},
+		{
+			name: "expanded field",
+			filters: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "status", Operator: FilterOperatorEqual, Value: "new"},
+						{Field: "name", Operator: FilterOperatorContains, Value: "jo"},
+					},
+				},
+			},
+			want: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "orders.status", Operator: FilterOperatorEqual, Value: "new"},
+					},
+					Filters: []Filters{
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "customers.first_name", Operator: FilterOperatorContains, Value: "jo"},
+									{Field: "customers.last_name", Operator: FilterOperatorContains, Value: "jo"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantValidationErrors: nil,
+		},
+		{
+			name: "expanded field with a negated operator",
+			filters: Filters{
+				Conditions: FilterConditions{
+					Filters: []Filters{
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "name", Operator: FilterOperatorNotContains, Value: "jo"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: Filters{
+				Conditions: FilterConditions{
+					Filters: []Filters{
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Filters: []Filters{
+									{
+										Logic: FilterLogicAnd,
+										Conditions: FilterConditions{
+											Conditions: []FilterCondition{
+												{Field: "customers.first_name", Operator: FilterOperatorNotContains, Value: "jo"},
+												{Field: "customers.last_name", Operator: FilterOperatorNotContains, Value: "jo"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantValidationErrors: nil,
+		},
+		{
+			name: "expanded field with nil and empty",
+			filters: Filters{
+				Logic: FilterLogicOr,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "name", Operator: FilterOperatorIsNil},
+						{Field: "name", Operator: FilterOperatorIsEmpty},
+						{Field: "name", Operator: FilterOperatorIsNotEmpty},
+					},
+				},
+			},
+			want: Filters{
+				Logic: FilterLogicOr,
+				Conditions: FilterConditions{
+					Filters: []Filters{
+						{
+							Logic: FilterLogicAnd,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "customers.first_name", Operator: FilterOperatorIsNil},
+									{Field: "customers.last_name", Operator: FilterOperatorIsNil},
+								},
+							},
+						},
+						{
+							Logic: FilterLogicAnd,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "customers.first_name", Operator: FilterOperatorIsEmpty},
+									{Field: "customers.last_name", Operator: FilterOperatorIsEmpty},
+								},
+							},
+						},
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "customers.first_name", Operator: FilterOperatorIsNotEmpty},
+									{Field: "customers.last_name", Operator: FilterOperatorIsNotEmpty},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantValidationErrors: nil,
+		},
+		{
+			name: "unmapped fields",
+			filters: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "status", Operator: FilterOperatorEqual, Value: "new"},
+						{Field: "password", Operator: FilterOperatorEqual, Value: "x"},
+					},
+					Filters: []Filters{
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "orders.status", Operator: FilterOperatorEqual, Value: "new"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: Filters{},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.conditions.0.field", Error: ValidationErrorUnmappedField, Field: "field", Payload: "orders.status"},
+				{Path: "root.conditions.1.field", Error: ValidationErrorUnmappedField, Field: "field", Payload: "password"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, validationErrors := tt.filters.MapFields(mapping)
+			if !reflect.DeepEqual(validationErrors, tt.wantValidationErrors) {
+				t.Errorf("MapFields() validationErrors = %v, want %v", validationErrors, tt.wantValidationErrors)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MapFields() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
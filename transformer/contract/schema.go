@@ -0,0 +1,346 @@
+package contract
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FieldType string
+
+const (
+	FieldTypeString FieldType = "string"
+	FieldTypeInt    FieldType = "int"
+	FieldTypeFloat  FieldType = "float"
+	FieldTypeBool   FieldType = "bool"
+	FieldTypeDate   FieldType = "date"
+	FieldTypeEnum   FieldType = "enum"
+	FieldTypeUUID   FieldType = "uuid"
+
+	ValidationErrorUnknownField        = "unknown field"
+	ValidationErrorUnsupportedOperator = "unsupported field operator"
+	ValidationErrorInvalidValue        = "invalid value"
+	ValidationErrorTooManyValues       = "too many values"
+)
+
+// SchemaDateLayouts are accepted by FieldTypeDate (in this order)
+var SchemaDateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+var schemaUUIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// nullOperators are only allowed for nullable fields (empty/not-empty compare with an empty string, not with NULL)
+var nullOperators = []FilterOperator{
+	FilterOperatorIsNil,
+	FilterOperatorIsNotNil,
+	FilterOperatorGreaterThanOrEqualOrNil,
+	FilterOperatorLowerThanOrEqualOrNil,
+}
+
+var listOperators = []FilterOperator{FilterOperatorIn, FilterOperatorNotIn}
+
+var comparisonOperators = []FilterOperator{
+	FilterOperatorEqual,
+	FilterOperatorNotEqual,
+	FilterOperatorGreaterThan,
+	FilterOperatorGreaterThanOrEqual,
+	FilterOperatorGreaterThanOrEqualOrNil,
+	FilterOperatorLowerThan,
+	FilterOperatorLowerThanOrEqual,
+	FilterOperatorLowerThanOrEqualOrNil,
+	FilterOperatorIsNil,
+	FilterOperatorIsNotNil,
+	FilterOperatorIn,
+	FilterOperatorNotIn,
+}
+
+var equalityOperators = []FilterOperator{
+	FilterOperatorEqual,
+	FilterOperatorNotEqual,
+	FilterOperatorIsNil,
+	FilterOperatorIsNotNil,
+	FilterOperatorIn,
+	FilterOperatorNotIn,
+}
+
+// defaultFieldTypeOperators are used when FieldSchema.Operators is empty
+var defaultFieldTypeOperators = map[FieldType][]FilterOperator{
+	FieldTypeString: supportedOperators,
+	FieldTypeInt:    comparisonOperators,
+	FieldTypeFloat:  comparisonOperators,
+	FieldTypeDate:   comparisonOperators,
+	FieldTypeBool:   {FilterOperatorEqual, FilterOperatorNotEqual, FilterOperatorIsNil, FilterOperatorIsNotNil},
+	FieldTypeEnum:   equalityOperators,
+	FieldTypeUUID:   equalityOperators,
+}
+
+// SchemaValidationPayload is the payload of the validation errors produced by Schema
+type SchemaValidationPayload struct {
+	Field    string
+	Expected string
+	Actual   any
+}
+
+type FieldSchema struct {
+	Type FieldType
+	// Operators defaults to the operators that make sense for the type (e.g. no begins/contains/ends for numbers)
+	Operators []FilterOperator
+	// Nullable allows nil, not-null, gten and lten
+	Nullable bool
+	// Values lists the allowed values of FieldTypeEnum
+	Values []string
+	// MaxListLength limits the number of values of in/not-in, 0 means no limit
+	MaxListLength int
+}
+
+// Schema maps the fields to their schema, fields missing in the schema are rejected
+type Schema map[string]FieldSchema
+
+func (s FieldSchema) operators() []FilterOperator {
+	operators := s.Operators
+	if len(operators) == 0 {
+		operators = defaultFieldTypeOperators[s.Type]
+	}
+	if s.Nullable {
+		return operators
+	}
+	return slices.DeleteFunc(slices.Clone(operators), func(operator FilterOperator) bool {
+		return slices.Contains(nullOperators, operator)
+	})
+}
+
+func (s FieldSchema) expected() string {
+	if s.Type == FieldTypeEnum {
+		return fmt.Sprintf("enum(%s)", strings.Join(s.Values, ", "))
+	}
+	return string(s.Type)
+}
+
+func parseSchemaInt(value any) (any, bool) {
+	reflectedValue := reflect.ValueOf(value)
+	switch {
+	case reflectedValue.CanInt():
+		return reflectedValue.Int(), true
+	case reflectedValue.CanUint() && reflectedValue.Uint() <= math.MaxInt64:
+		return int64(reflectedValue.Uint()), true
+	case reflectedValue.CanFloat():
+		// JSON numbers are decoded as float64
+		number := reflectedValue.Float()
+		if number != math.Trunc(number) || number < math.MinInt64 || number >= math.MaxInt64 {
+			return nil, false
+		}
+		return int64(number), true
+	case reflectedValue.Kind() == reflect.String:
+		number, err := strconv.ParseInt(strings.TrimSpace(reflectedValue.String()), 10, 64)
+		return number, err == nil
+	}
+	return nil, false
+}
+
+func parseSchemaFloat(value any) (any, bool) {
+	reflectedValue := reflect.ValueOf(value)
+	switch {
+	case reflectedValue.CanInt():
+		return float64(reflectedValue.Int()), true
+	case reflectedValue.CanUint():
+		return float64(reflectedValue.Uint()), true
+	case reflectedValue.CanFloat():
+		return reflectedValue.Float(), true
+	case reflectedValue.Kind() == reflect.String:
+		number, err := strconv.ParseFloat(strings.TrimSpace(reflectedValue.String()), 64)
+		return number, err == nil && !math.IsNaN(number) && !math.IsInf(number, 0)
+	}
+	return nil, false
+}
+
+func parseSchemaBool(value any) (any, bool) {
+	switch typedValue := value.(type) {
+	case bool:
+		return typedValue, true
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(typedValue))
+		return parsed, err == nil
+	}
+	return nil, false
+}
+
+func parseSchemaDate(value any) (any, bool) {
+	switch typedValue := value.(type) {
+	case time.Time:
+		return typedValue, true
+	case string:
+		for _, layout := range SchemaDateLayouts {
+			if parsed, err := time.Parse(layout, strings.TrimSpace(typedValue)); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parseSchemaUUID returns the UUID in lower case, so it matches the canonical form stored by most databases
+func parseSchemaUUID(value any) (any, bool) {
+	typedValue, isString := value.(string)
+	typedValue = strings.TrimSpace(typedValue)
+	if !isString || !schemaUUIDPattern.MatchString(typedValue) {
+		return nil, false
+	}
+	return strings.ToLower(typedValue), true
+}
+
+// parseSchemaString accepts numbers and booleans as well, as some inputs (e.g. the Shorthand input) decode unquoted values
+func parseSchemaString(value any) (any, bool) {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue, true
+	case bool:
+		return strconv.FormatBool(typedValue), true
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64), true
+	}
+	reflectedValue := reflect.ValueOf(value)
+	switch {
+	case reflectedValue.CanInt():
+		return strconv.FormatInt(reflectedValue.Int(), 10), true
+	case reflectedValue.CanUint():
+		return strconv.FormatUint(reflectedValue.Uint(), 10), true
+	}
+	return nil, false
+}
+
+// parseValue converts the value to the type of the field, false is returned for values that can't be converted
+func (s FieldSchema) parseValue(value any) (any, bool) {
+	switch s.Type {
+	case FieldTypeString:
+		return parseSchemaString(value)
+	case FieldTypeInt:
+		return parseSchemaInt(value)
+	case FieldTypeFloat:
+		return parseSchemaFloat(value)
+	case FieldTypeBool:
+		return parseSchemaBool(value)
+	case FieldTypeDate:
+		return parseSchemaDate(value)
+	case FieldTypeEnum:
+		parsed, isString := value.(string)
+		return parsed, isString && slices.Contains(s.Values, parsed)
+	case FieldTypeUUID:
+		return parseSchemaUUID(value)
+	}
+	return nil, false
+}
+
+func (s FieldSchema) validateValue(field string, value any, path string, validationErrors *[]ValidationError) {
+	if _, isValid := s.parseValue(value); isValid {
+		return
+	}
+	*validationErrors = append(*validationErrors, ValidationError{
+		Path:    path,
+		Error:   ValidationErrorInvalidValue,
+		Field:   "value",
+		Payload: SchemaValidationPayload{Field: field, Expected: s.expected(), Actual: value},
+	})
+}
+
+// Validate can be used as the ValidationFunc (`WithValidationFunc(schema.Validate)`), it checks that the field is known,
+// the operator is allowed for the field and that the value (each value of in/not-in) can be converted to the type of the field
+func (s Schema) Validate(filterCondition FilterCondition, path string, validationErrors *[]ValidationError) {
+	if filterCondition.Field == "" || !slices.Contains(supportedOperators, filterCondition.Operator) {
+		// reported by Filters.Validate
+		return
+	}
+	fieldSchema, isKnown := s[filterCondition.Field]
+	if !isKnown {
+		*validationErrors = append(*validationErrors, ValidationError{
+			Path:    fmt.Sprintf("%s.field", path),
+			Error:   ValidationErrorUnknownField,
+			Field:   "field",
+			Payload: filterCondition.Field,
+		})
+		return
+	}
+	if operators := fieldSchema.operators(); !slices.Contains(operators, filterCondition.Operator) {
+		expected := make([]string, 0, len(operators))
+		for _, operator := range operators {
+			expected = append(expected, string(operator))
+		}
+		*validationErrors = append(*validationErrors, ValidationError{
+			Path:    fmt.Sprintf("%s.operator", path),
+			Error:   ValidationErrorUnsupportedOperator,
+			Field:   "operator",
+			Payload: SchemaValidationPayload{Field: filterCondition.Field, Expected: strings.Join(expected, ", "), Actual: string(filterCondition.Operator)},
+		})
+		return
+	}
+	switch {
+	case slices.Contains([]FilterOperator{FilterOperatorIsNil, FilterOperatorIsNotNil, FilterOperatorIsEmpty, FilterOperatorIsNotEmpty}, filterCondition.Operator):
+		// the value is not used
+	case slices.Contains(listOperators, filterCondition.Operator):
+		values := filterCondition.ValueList()
+		if fieldSchema.MaxListLength > 0 && len(values) > fieldSchema.MaxListLength {
+			*validationErrors = append(*validationErrors, ValidationError{
+				Path:    fmt.Sprintf("%s.value", path),
+				Error:   ValidationErrorTooManyValues,
+				Field:   "value",
+				Payload: SchemaValidationPayload{Field: filterCondition.Field, Expected: fmt.Sprintf("at most %d values", fieldSchema.MaxListLength), Actual: len(values)},
+			})
+			return
+		}
+		for index, value := range values {
+			fieldSchema.validateValue(filterCondition.Field, value, fmt.Sprintf("%s.value.%d", path, index), validationErrors)
+		}
+	default:
+		fieldSchema.validateValue(filterCondition.Field, filterCondition.Value, fmt.Sprintf("%s.value", path), validationErrors)
+	}
+}
+
+// coerceValue converts the value (each value of in/not-in) of a valid condition to the type of the field
+func (s FieldSchema) coerceValue(condition FilterCondition) any {
+	switch {
+	case slices.Contains([]FilterOperator{FilterOperatorIsNil, FilterOperatorIsNotNil, FilterOperatorIsEmpty, FilterOperatorIsNotEmpty}, condition.Operator):
+		return condition.Value
+	case slices.Contains(listOperators, condition.Operator):
+		values := condition.ValueList()
+		coerced := make([]any, 0, len(values))
+		for _, value := range values {
+			parsed, _ := s.parseValue(value)
+			coerced = append(coerced, parsed)
+		}
+		return coerced
+	}
+	parsed, _ := s.parseValue(condition.Value)
+	return parsed
+}
+
+func (s Schema) coerceFilters(filters Filters, path string, validationErrors *[]ValidationError) Filters {
+	coerced := Filters{Logic: filters.Logic}
+	for index, filter := range filters.Conditions.Filters {
+		coerced.Conditions.Filters = append(coerced.Conditions.Filters, s.coerceFilters(filter, fmt.Sprintf("%s.conditions.%d", path, index), validationErrors))
+	}
+	for index, condition := range filters.Conditions.Conditions {
+		errorCount := len(*validationErrors)
+		s.Validate(condition, fmt.Sprintf("%s.conditions.%d", path, index), validationErrors)
+		if len(*validationErrors) == errorCount {
+			if fieldSchema, isKnown := s[condition.Field]; isKnown {
+				condition.Value = fieldSchema.coerceValue(condition)
+			}
+		}
+		coerced.Conditions.Conditions = append(coerced.Conditions.Conditions, condition)
+	}
+	return coerced
+}
+
+// Coerce validates the filters (see Validate) and returns a copy with the values converted to the types of the fields
+// (int64, float64, bool, time.Time, lower-case UUID strings or enum values; in/not-in values become []any)
+func (s Schema) Coerce(filters Filters) (Filters, []ValidationError) {
+	var validationErrors []ValidationError
+	coerced := s.coerceFilters(filters, "root", &validationErrors)
+	if len(validationErrors) > 0 {
+		return Filters{}, validationErrors
+	}
+	return coerced, nil
+}
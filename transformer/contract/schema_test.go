@@ -0,0 +1,292 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"name":       {Type: FieldTypeString},
+	"age":        {Type: FieldTypeInt, Nullable: true},
+	"price":      {Type: FieldTypeFloat, Operators: []FilterOperator{FilterOperatorGreaterThan, FilterOperatorLowerThan}},
+	"active":     {Type: FieldTypeBool},
+	"created_at": {Type: FieldTypeDate},
+	"status":     {Type: FieldTypeEnum, Values: []string{"new", "paid"}, MaxListLength: 2},
+	"id":         {Type: FieldTypeUUID},
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		condition            FilterCondition
+		wantValidationErrors []ValidationError
+	}{
+		{
+			name:                 "string",
+			condition:            FilterCondition{Field: "name", Operator: FilterOperatorBegins, Value: "Jo"},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "string from a number",
+			condition:            FilterCondition{Field: "name", Operator: FilterOperatorEqual, Value: 123.0},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "int from JSON",
+			condition:            FilterCondition{Field: "age", Operator: FilterOperatorGreaterThanOrEqual, Value: 18.0},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "int from a string",
+			condition:            FilterCondition{Field: "age", Operator: FilterOperatorLowerThanOrEqualOrNil, Value: "65"},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "nullable",
+			condition:            FilterCondition{Field: "age", Operator: FilterOperatorIsNil},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "float",
+			condition:            FilterCondition{Field: "price", Operator: FilterOperatorGreaterThan, Value: "9.99"},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "bool",
+			condition:            FilterCondition{Field: "active", Operator: FilterOperatorEqual, Value: "true"},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "date",
+			condition:            FilterCondition{Field: "created_at", Operator: FilterOperatorGreaterThan, Value: "2024-01-01"},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "enum list",
+			condition:            FilterCondition{Field: "status", Operator: FilterOperatorIn, Value: []string{"new", "paid"}},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "uuid",
+			condition:            FilterCondition{Field: "id", Operator: FilterOperatorEqual, Value: "6F9619FF-8B86-D011-B42D-00C04FC964FF"},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "empty string of a field that is not nullable",
+			condition:            FilterCondition{Field: "name", Operator: FilterOperatorIsNotEmpty},
+			wantValidationErrors: nil,
+		},
+		{
+			name:                 "unsupported operator is reported by Filters.Validate",
+			condition:            FilterCondition{Field: "age", Operator: "unknown", Value: 1.0},
+			wantValidationErrors: nil,
+		},
+		{
+			name:      "unknown field",
+			condition: FilterCondition{Field: "password", Operator: FilterOperatorEqual, Value: "x"},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.field", Error: ValidationErrorUnknownField, Field: "field", Payload: "password"},
+			},
+		},
+		{
+			name:      "operator not supported by the type",
+			condition: FilterCondition{Field: "age", Operator: FilterOperatorContains, Value: 1.0},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.operator", Error: ValidationErrorUnsupportedOperator, Field: "operator", Payload: SchemaValidationPayload{
+					Field:    "age",
+					Expected: "eq, neq, gt, gte, gten, lt, lte, lten, nil, not-null, in, not-in",
+					Actual:   "contains",
+				}},
+			},
+		},
+		{
+			name:      "operator not allowed by the schema",
+			condition: FilterCondition{Field: "price", Operator: FilterOperatorEqual, Value: 1.0},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.operator", Error: ValidationErrorUnsupportedOperator, Field: "operator", Payload: SchemaValidationPayload{Field: "price", Expected: "gt, lt", Actual: "eq"}},
+			},
+		},
+		{
+			name:      "not nullable",
+			condition: FilterCondition{Field: "created_at", Operator: FilterOperatorIsNil},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.operator", Error: ValidationErrorUnsupportedOperator, Field: "operator", Payload: SchemaValidationPayload{
+					Field:    "created_at",
+					Expected: "eq, neq, gt, gte, lt, lte, in, not-in",
+					Actual:   "nil",
+				}},
+			},
+		},
+		{
+			name:      "null check of a string field that is not nullable",
+			condition: FilterCondition{Field: "name", Operator: FilterOperatorIsNotNil},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.operator", Error: ValidationErrorUnsupportedOperator, Field: "operator", Payload: SchemaValidationPayload{
+					Field:    "name",
+					Expected: "eq, neq, gt, gte, lt, lte, begins, contains, not-contains, ends, empty, not-empty, in, not-in, match-phrase",
+					Actual:   "not-null",
+				}},
+			},
+		},
+		{
+			name:      "invalid int",
+			condition: FilterCondition{Field: "age", Operator: FilterOperatorEqual, Value: 18.5},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.value", Error: ValidationErrorInvalidValue, Field: "value", Payload: SchemaValidationPayload{Field: "age", Expected: "int", Actual: 18.5}},
+			},
+		},
+		{
+			name:      "null value",
+			condition: FilterCondition{Field: "active", Operator: FilterOperatorEqual, Value: nil},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.value", Error: ValidationErrorInvalidValue, Field: "value", Payload: SchemaValidationPayload{Field: "active", Expected: "bool", Actual: nil}},
+			},
+		},
+		{
+			name:      "invalid date",
+			condition: FilterCondition{Field: "created_at", Operator: FilterOperatorGreaterThan, Value: "yesterday"},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.value", Error: ValidationErrorInvalidValue, Field: "value", Payload: SchemaValidationPayload{Field: "created_at", Expected: "date", Actual: "yesterday"}},
+			},
+		},
+		{
+			name:      "invalid uuid",
+			condition: FilterCondition{Field: "id", Operator: FilterOperatorEqual, Value: "6f9619ff-8b86-d011-b42d"},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.value", Error: ValidationErrorInvalidValue, Field: "value", Payload: SchemaValidationPayload{Field: "id", Expected: "uuid", Actual: "6f9619ff-8b86-d011-b42d"}},
+			},
+		},
+		{
+			name:      "invalid enum values",
+			condition: FilterCondition{Field: "status", Operator: FilterOperatorNotIn, Value: []any{"new", "shipped"}},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.value.1", Error: ValidationErrorInvalidValue, Field: "value", Payload: SchemaValidationPayload{Field: "status", Expected: "enum(new, paid)", Actual: "shipped"}},
+			},
+		},
+		{
+			name:      "too many values",
+			condition: FilterCondition{Field: "status", Operator: FilterOperatorIn, Value: []string{"new", "paid", "new"}},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.value", Error: ValidationErrorTooManyValues, Field: "value", Payload: SchemaValidationPayload{Field: "status", Expected: "at most 2 values", Actual: 3}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErrors []ValidationError
+			testSchema.Validate(tt.condition, "root.conditions.0", &validationErrors)
+			if !reflect.DeepEqual(validationErrors, tt.wantValidationErrors) {
+				t.Errorf("Validate() validationErrors = %v, want %v", validationErrors, tt.wantValidationErrors)
+			}
+		})
+	}
+}
+
+func TestSchema_Coerce(t *testing.T) {
+	tests := []struct {
+		name                 string
+		filters              Filters
+		want                 Filters
+		wantValidationErrors []ValidationError
+	}{
+		{
+			name:                 "empty filters",
+			filters:              Filters{},
+			want:                 Filters{},
+			wantValidationErrors: nil,
+		},
+		{
+			name: "coerced values",
+			filters: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "age", Operator: FilterOperatorGreaterThanOrEqual, Value: 18.0},
+						{Field: "price", Operator: FilterOperatorLowerThan, Value: "9.99"},
+						{Field: "active", Operator: FilterOperatorEqual, Value: "true"},
+						{Field: "created_at", Operator: FilterOperatorGreaterThan, Value: "2024-01-01"},
+						{Field: "id", Operator: FilterOperatorEqual, Value: "6F9619FF-8B86-D011-B42D-00C04FC964FF"},
+						{Field: "age", Operator: FilterOperatorIsNil},
+					},
+					Filters: []Filters{
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "status", Operator: FilterOperatorIn, Value: []string{"new", "paid"}},
+									{Field: "age", Operator: FilterOperatorNotIn, Value: []any{"1", 2.0}},
+									{Field: "name", Operator: FilterOperatorEqual, Value: 123.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "age", Operator: FilterOperatorGreaterThanOrEqual, Value: int64(18)},
+						{Field: "price", Operator: FilterOperatorLowerThan, Value: 9.99},
+						{Field: "active", Operator: FilterOperatorEqual, Value: true},
+						{Field: "created_at", Operator: FilterOperatorGreaterThan, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+						{Field: "id", Operator: FilterOperatorEqual, Value: "6f9619ff-8b86-d011-b42d-00c04fc964ff"},
+						{Field: "age", Operator: FilterOperatorIsNil},
+					},
+					Filters: []Filters{
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "status", Operator: FilterOperatorIn, Value: []any{"new", "paid"}},
+									{Field: "age", Operator: FilterOperatorNotIn, Value: []any{int64(1), int64(2)}},
+									{Field: "name", Operator: FilterOperatorEqual, Value: "123"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantValidationErrors: nil,
+		},
+		{
+			name: "values that can't be coerced",
+			filters: Filters{
+				Logic: FilterLogicAnd,
+				Conditions: FilterConditions{
+					Conditions: []FilterCondition{
+						{Field: "age", Operator: FilterOperatorEqual, Value: "adult"},
+						{Field: "active", Operator: FilterOperatorEqual, Value: true},
+					},
+					Filters: []Filters{
+						{
+							Logic: FilterLogicOr,
+							Conditions: FilterConditions{
+								Conditions: []FilterCondition{
+									{Field: "id", Operator: FilterOperatorIn, Value: []any{"6f9619ff-8b86-d011-b42d-00c04fc964ff", 1.0}},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: Filters{},
+			wantValidationErrors: []ValidationError{
+				{Path: "root.conditions.0.conditions.0.value.1", Error: ValidationErrorInvalidValue, Field: "value", Payload: SchemaValidationPayload{Field: "id", Expected: "uuid", Actual: 1.0}},
+				{Path: "root.conditions.0.value", Error: ValidationErrorInvalidValue, Field: "value", Payload: SchemaValidationPayload{Field: "age", Expected: "int", Actual: "adult"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, validationErrors := testSchema.Coerce(tt.filters)
+			if !reflect.DeepEqual(validationErrors, tt.wantValidationErrors) {
+				t.Errorf("Coerce() validationErrors = %v, want %v", validationErrors, tt.wantValidationErrors)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Coerce() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
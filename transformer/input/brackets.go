@@ -0,0 +1,203 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+var (
+	errMissingKeyName      = errors.New("missing key name")
+	errUnclosedBracket     = errors.New("unclosed bracket")
+	errUnexpectedBracket   = errors.New("unexpected bracket")
+	errEmptyIndexNotLast   = errors.New("empty index is only allowed at the end of the key")
+	errConflictingKeyValue = errors.New("key conflicts with another key")
+)
+
+func newMalformedKeyError(key string, err error) *contract.Error {
+	return contract.NewError(contract.InvalidInputDataStructure, fmt.Sprintf("%s: %s", key, err.Error()))
+}
+
+// splitBracketKey splits a key like `filter[conditions][0][value][]` into its segments
+// (`filter`, `conditions`, `0`, `value` and an empty segment)
+func splitBracketKey(key string) ([]string, error) {
+	index := strings.IndexByte(key, '[')
+	if index == -1 {
+		if key == "" {
+			return nil, errMissingKeyName
+		}
+		if strings.IndexByte(key, ']') != -1 {
+			return nil, errUnexpectedBracket
+		}
+		return []string{key}, nil
+	}
+	if index == 0 {
+		return nil, errMissingKeyName
+	}
+	if strings.IndexByte(key[:index], ']') != -1 {
+		return nil, errUnexpectedBracket
+	}
+	segments := []string{key[:index]}
+	rest := key[index:]
+	for rest != "" {
+		if rest[0] != '[' {
+			return nil, errUnexpectedBracket
+		}
+		end := strings.IndexByte(rest, ']')
+		if end == -1 {
+			return nil, errUnclosedBracket
+		}
+		segment := rest[1:end]
+		if strings.IndexByte(segment, '[') != -1 {
+			return nil, errUnexpectedBracket
+		}
+		segments = append(segments, segment)
+		rest = rest[end+1:]
+	}
+	for index, segment := range segments {
+		if segment == "" && index < len(segments)-1 {
+			return nil, errEmptyIndexNotLast
+		}
+	}
+	return segments, nil
+}
+
+func isBracketKeyUnder(key string, rootKey string) bool {
+	return key == rootKey || strings.HasPrefix(key, rootKey+"[")
+}
+
+func insertBracketValue(node map[string]any, segments []string, value any) error {
+	for _, segment := range segments[:len(segments)-1] {
+		child, exists := node[segment]
+		if !exists {
+			child = make(map[string]any)
+			node[segment] = child
+		}
+		childNode, isNode := child.(map[string]any)
+		if !isNode {
+			return errConflictingKeyValue
+		}
+		node = childNode
+	}
+	last := segments[len(segments)-1]
+	if _, exists := node[last]; exists {
+		return errConflictingKeyValue
+	}
+	node[last] = value
+	return nil
+}
+
+// normalizeBracketTree turns nodes with purely numeric keys into arrays ordered by index
+func normalizeBracketTree(value any) any {
+	node, isNode := value.(map[string]any)
+	if !isNode {
+		return value
+	}
+	indices := make([]int, 0, len(node))
+	byIndex := make(map[int]string, len(node))
+	for key := range node {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || strconv.Itoa(index) != key {
+			indices = nil
+			break
+		}
+		indices = append(indices, index)
+		byIndex[index] = key
+	}
+	if len(indices) > 0 {
+		sort.Ints(indices)
+		array := make([]any, len(indices))
+		for position, index := range indices {
+			array[position] = normalizeBracketTree(node[byIndex[index]])
+		}
+		return array
+	}
+	normalized := make(map[string]any, len(node))
+	for key, child := range node {
+		normalized[key] = normalizeBracketTree(child)
+	}
+	return normalized
+}
+
+// buildBracketTree builds a nested structure out of bracket-notation keys;
+// if rootKey is not empty, only keys nested under the root key are considered
+func buildBracketTree(data map[string][]string, rootKey string) (map[string]any, *contract.Error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if rootKey == "" || isBracketKeyUnder(key, rootKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	tree := make(map[string]any)
+	for _, key := range keys {
+		segments, err := splitBracketKey(key)
+		if err != nil {
+			return nil, newMalformedKeyError(key, err)
+		}
+		var value any
+		values := data[key]
+		isList := segments[len(segments)-1] == ""
+		if isList {
+			segments = segments[:len(segments)-1]
+		}
+		if isList || len(values) > 1 {
+			// `key[]=a&key[]=b` (or a repeated key) holds a list of values
+			array := make([]any, len(values))
+			for index, item := range values {
+				array[index] = item
+			}
+			value = array
+		} else if len(values) == 1 {
+			value = values[0]
+		}
+		if err := insertBracketValue(tree, segments, value); err != nil {
+			return nil, newMalformedKeyError(key, err)
+		}
+	}
+	for key, child := range tree {
+		tree[key] = normalizeBracketTree(child)
+	}
+	return tree, nil
+}
+
+func marshalBracketTree(data map[string][]string) ([]byte, error) {
+	if data == nil {
+		return nil, nil
+	}
+	tree, err := buildBracketTree(data, "")
+	if err != nil {
+		return nil, err.Err
+	}
+	return json.Marshal(tree)
+}
+
+// transformBracketTree transforms the filter structure found under the root key
+// (or the whole structure if the root key is empty) the same way as the JSON input would be transformed
+func transformBracketTree(data map[string][]string, rootKey string) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	tree, err := buildBracketTree(data, rootKey)
+	if err != nil {
+		return filters, err
+	}
+	var root any = tree
+	if rootKey != "" {
+		root = tree[rootKey]
+	}
+	if root == nil || len(tree) == 0 {
+		return filters, nil
+	}
+	rawData, marshalErr := json.Marshal(root)
+	if marshalErr != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, marshalErr.Error())
+	}
+	jsonInput, _ := contract.NewInputOutputType(rawData, &JsonInput{})
+	jsonInputTransformer := JsonInputTransformer{}
+	return jsonInputTransformer.Transform(jsonInput)
+}
@@ -0,0 +1,371 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+// elasticKeywordSuffix is appended to text fields by the Elastic output transformer
+const elasticKeywordSuffix = ".lowersortable"
+
+var elasticRangeOperators = map[string]contract.FilterOperator{
+	"gt":  contract.FilterOperatorGreaterThan,
+	"gte": contract.FilterOperatorGreaterThanOrEqual,
+	"lt":  contract.FilterOperatorLowerThan,
+	"lte": contract.FilterOperatorLowerThanOrEqual,
+}
+
+var elasticBoolOccurrences = []string{"must", "filter", "should", "must_not", "minimum_should_match"}
+
+// ElasticUnsupportedClause is the payload of the error returned for a part of the query that can't be converted to filters
+type ElasticUnsupportedClause struct {
+	Path   string
+	Clause string
+	Error  string
+}
+
+func newElasticClauseError(path string, clause string, message string) *contract.Error {
+	return contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{
+		Path:   path,
+		Clause: clause,
+		Error:  message,
+	})
+}
+
+func joinElasticPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+func stripElasticKeywordSuffix(field string) string {
+	return strings.TrimSuffix(field, elasticKeywordSuffix)
+}
+
+// elasticClauseList accepts both a single clause and an array of clauses like Elasticsearch does
+func elasticClauseList(raw any) ([]any, bool) {
+	switch typedRaw := raw.(type) {
+	case []any:
+		return typedRaw, true
+	case []map[string]any:
+		clauses := make([]any, 0, len(typedRaw))
+		for _, clause := range typedRaw {
+			clauses = append(clauses, clause)
+		}
+		return clauses, true
+	case map[string]any:
+		return []any{typedRaw}, true
+	}
+	return nil, false
+}
+
+// singleElasticEntry returns the only key of a clause such as `{"term": {...}}` or `{"field": value}`
+func singleElasticEntry(raw any) (string, any, bool) {
+	object, isObject := raw.(map[string]any)
+	if !isObject || len(object) != 1 {
+		return "", nil, false
+	}
+	for key, value := range object {
+		return key, value, true
+	}
+	return "", nil, false
+}
+
+// unwrapElasticValue accepts both the short `{"field": value}` and the long `{"field": {"<key>": value}}` form
+func unwrapElasticValue(raw any, key string) (any, bool) {
+	object, isObject := raw.(map[string]any)
+	if !isObject {
+		return raw, true
+	}
+	value, hasValue := object[key]
+	return value, hasValue && len(object) == 1
+}
+
+func isElasticMinimumShouldMatchOne(raw any) bool {
+	switch typedRaw := raw.(type) {
+	case int:
+		return typedRaw == 1
+	case float64:
+		return typedRaw == 1
+	case string:
+		number, err := strconv.Atoi(typedRaw)
+		return err == nil && number == 1
+	}
+	return false
+}
+
+func parseElasticQuery(raw any, path string) (contract.Filters, *contract.Error) {
+	clause, body, ok := singleElasticEntry(raw)
+	if !ok {
+		return contract.Filters{}, newElasticClauseError(path, "", "query must be an object with exactly one clause")
+	}
+	clausePath := joinElasticPath(path, clause)
+	switch clause {
+	case "bool":
+		return parseElasticBool(body, clausePath)
+	case "exists":
+		object, isObject := body.(map[string]any)
+		field, isString := object["field"].(string)
+		if !isObject || !isString || len(object) != 1 {
+			return contract.Filters{}, newElasticClauseError(clausePath, clause, "expected an object with a single field")
+		}
+		return newConditionFilters(contract.FilterCondition{Field: field, Operator: contract.FilterOperatorIsNotNil}), nil
+	case "range":
+		return parseElasticRange(body, clausePath)
+	case "term", "terms", "prefix", "wildcard", "match_phrase":
+		field, value, ok := singleElasticEntry(body)
+		if !ok {
+			return contract.Filters{}, newElasticClauseError(clausePath, clause, "expected an object with a single field")
+		}
+		condition, err := newElasticFieldCondition(clause, field, value, joinElasticPath(clausePath, field))
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		return newConditionFilters(condition), nil
+	}
+	return contract.Filters{}, newElasticClauseError(clausePath, clause, "unsupported query clause")
+}
+
+func newElasticFieldCondition(clause string, field string, raw any, path string) (contract.FilterCondition, *contract.Error) {
+	condition := contract.FilterCondition{Field: stripElasticKeywordSuffix(field)}
+	switch clause {
+	case "term":
+		value, ok := unwrapElasticValue(raw, "value")
+		if !ok {
+			return condition, newElasticClauseError(path, clause, "unsupported term parameters")
+		}
+		condition.Operator = contract.FilterOperatorEqual
+		condition.Value = value
+	case "terms":
+		switch raw.(type) {
+		case []any, []string:
+		default:
+			return condition, newElasticClauseError(path, clause, "expected an array of values")
+		}
+		condition.Operator = contract.FilterOperatorIn
+		condition.Value = raw
+	case "prefix":
+		value, ok := unwrapElasticValue(raw, "value")
+		if !ok {
+			return condition, newElasticClauseError(path, clause, "unsupported prefix parameters")
+		}
+		condition.Operator = contract.FilterOperatorBegins
+		condition.Value = value
+	case "wildcard":
+		value, ok := unwrapElasticValue(raw, "value")
+		pattern, isString := value.(string)
+		if !ok || !isString {
+			return condition, newElasticClauseError(path, clause, "unsupported wildcard parameters")
+		}
+		text, leading, trailing, problem := parseWildcardPattern(pattern, '*')
+		if problem != "" || strings.ContainsRune(pattern, '?') || (!leading && !trailing) {
+			return condition, newElasticClauseError(path, clause, fmt.Sprintf("unsupported wildcard pattern %q", pattern))
+		}
+		condition.Value = text
+		switch {
+		case leading && trailing:
+			condition.Operator = contract.FilterOperatorContains
+		case leading:
+			condition.Operator = contract.FilterOperatorEnds
+		default:
+			condition.Operator = contract.FilterOperatorBegins
+		}
+	case "match_phrase":
+		value, ok := unwrapElasticValue(raw, "query")
+		if !ok {
+			return condition, newElasticClauseError(path, clause, "unsupported match_phrase parameters")
+		}
+		condition.Operator = contract.FilterOperatorMatchPhrase
+		condition.Value = value
+	}
+	return condition, nil
+}
+
+// parseElasticRange converts each bound to a condition, multiple bounds of a single range are joined using AND
+func parseElasticRange(raw any, path string) (contract.Filters, *contract.Error) {
+	field, body, ok := singleElasticEntry(raw)
+	bounds, isObject := body.(map[string]any)
+	if !ok || !isObject || len(bounds) == 0 {
+		return contract.Filters{}, newElasticClauseError(path, "range", "expected an object with a single field")
+	}
+	path = joinElasticPath(path, field)
+	var nodes []contract.Filters
+	for _, bound := range []string{"gt", "gte", "lt", "lte"} {
+		if value, hasBound := bounds[bound]; hasBound {
+			nodes = append(nodes, newConditionFilters(contract.FilterCondition{
+				Field:    field,
+				Operator: elasticRangeOperators[bound],
+				Value:    value,
+			}))
+		}
+	}
+	for parameter := range bounds {
+		if _, isBound := elasticRangeOperators[parameter]; !isBound {
+			return contract.Filters{}, newElasticClauseError(joinElasticPath(path, parameter), "range", "unsupported range parameter")
+		}
+	}
+	return combineFilters(contract.FilterLogicAnd, nodes), nil
+}
+
+// parseElasticOrNil recognizes `range OR must_not exists` on the same field emitted for the gten and lten operators
+func parseElasticOrNil(clauses []any) (contract.FilterCondition, bool) {
+	if len(clauses) != 2 {
+		return contract.FilterCondition{}, false
+	}
+	rangeFilters, err := parseElasticQuery(clauses[0], "")
+	if err != nil || len(rangeFilters.Conditions.Conditions) != 1 || len(rangeFilters.Conditions.Filters) != 0 {
+		return contract.FilterCondition{}, false
+	}
+	missingFilters, err := parseElasticQuery(clauses[1], "")
+	if err != nil || len(missingFilters.Conditions.Conditions) != 1 || len(missingFilters.Conditions.Filters) != 0 {
+		return contract.FilterCondition{}, false
+	}
+	condition := rangeFilters.Conditions.Conditions[0]
+	missing := missingFilters.Conditions.Conditions[0]
+	if missing.Operator != contract.FilterOperatorIsNil || missing.Field != condition.Field {
+		return contract.FilterCondition{}, false
+	}
+	switch condition.Operator {
+	case contract.FilterOperatorGreaterThanOrEqual:
+		condition.Operator = contract.FilterOperatorGreaterThanOrEqualOrNil
+	case contract.FilterOperatorLowerThanOrEqual:
+		condition.Operator = contract.FilterOperatorLowerThanOrEqualOrNil
+	default:
+		return contract.FilterCondition{}, false
+	}
+	return condition, true
+}
+
+func parseElasticClauses(raw any, path string, negate bool) ([]contract.Filters, *contract.Error) {
+	clauses, ok := elasticClauseList(raw)
+	if !ok {
+		return nil, newElasticClauseError(path, "", "expected an array of queries")
+	}
+	var nodes []contract.Filters
+	for index, clause := range clauses {
+		clausePath := joinElasticPath(path, strconv.Itoa(index))
+		node, err := parseElasticQuery(clause, clausePath)
+		if err != nil {
+			return nil, err
+		}
+		if negate {
+			negated, operator, ok := negateFilters(node)
+			if !ok {
+				return nil, newElasticClauseError(clausePath, "", fmt.Sprintf("operator %q can't be negated", operator))
+			}
+			node = negated
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// parseElasticBool joins must, filter and negated must_not clauses using AND;
+// should clauses are joined using OR and are only allowed to match at least one clause
+func parseElasticBool(raw any, path string) (contract.Filters, *contract.Error) {
+	occurrences, isObject := raw.(map[string]any)
+	if !isObject {
+		return contract.Filters{}, newElasticClauseError(path, "bool", "expected an object")
+	}
+	for occurrence := range occurrences {
+		if !slices.Contains(elasticBoolOccurrences, occurrence) {
+			return contract.Filters{}, newElasticClauseError(joinElasticPath(path, occurrence), "bool", "unsupported bool parameter")
+		}
+	}
+
+	var nodes []contract.Filters
+	for _, occurrence := range []string{"must", "filter", "must_not"} {
+		if clauses, hasClauses := occurrences[occurrence]; hasClauses {
+			occurrenceNodes, err := parseElasticClauses(clauses, joinElasticPath(path, occurrence), occurrence == "must_not")
+			if err != nil {
+				return contract.Filters{}, err
+			}
+			nodes = append(nodes, occurrenceNodes...)
+		}
+	}
+
+	shouldClauses, hasShould := occurrences["should"]
+	minimumShouldMatch, hasMinimumShouldMatch := occurrences["minimum_should_match"]
+	if hasMinimumShouldMatch && !isElasticMinimumShouldMatchOne(minimumShouldMatch) {
+		return contract.Filters{}, newElasticClauseError(joinElasticPath(path, "minimum_should_match"), "bool", "only minimum_should_match of 1 is supported")
+	}
+	if !hasShould {
+		return combineFilters(contract.FilterLogicAnd, nodes), nil
+	}
+	if len(nodes) > 0 && !hasMinimumShouldMatch {
+		// should clauses next to must clauses only affect scoring unless minimum_should_match is set
+		return contract.Filters{}, newElasticClauseError(joinElasticPath(path, "should"), "bool", "should clauses combined with must clauses require minimum_should_match of 1")
+	}
+	if clauses, ok := elasticClauseList(shouldClauses); ok {
+		if condition, isOrNil := parseElasticOrNil(clauses); isOrNil {
+			return combineFilters(contract.FilterLogicAnd, append(nodes, newConditionFilters(condition))), nil
+		}
+	}
+	shouldNodes, err := parseElasticClauses(shouldClauses, joinElasticPath(path, "should"), false)
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	if len(shouldNodes) == 0 {
+		return combineFilters(contract.FilterLogicAnd, nodes), nil
+	}
+	return combineFilters(contract.FilterLogicAnd, append(nodes, combineFilters(contract.FilterLogicOr, shouldNodes))), nil
+}
+
+type ElasticInput struct {
+	contract.InputOutputType[map[string]any]
+}
+
+func (i *ElasticInput) GetDataString() (string, error) {
+	rawData, err := i.GetDataJson()
+	if err != nil {
+		return "", err
+	}
+	return string(rawData), nil
+}
+
+func (i *ElasticInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == nil {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+// NewElasticInputFromJson decodes a stored query such as `{"bool": {...}}` or `{"query": {"bool": {...}}}`
+func NewElasticInputFromJson(data []byte) (*ElasticInput, error) {
+	var query map[string]any
+	if err := json.Unmarshal(data, &query); err != nil {
+		return nil, err
+	}
+	return contract.NewInputOutputType(query, &ElasticInput{})
+}
+
+type ElasticInputTransformer struct {
+}
+
+func (t *ElasticInputTransformer) Transform(input *ElasticInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if len(rawData) == 0 {
+		return filters, nil
+	}
+	var query any = rawData
+	path := ""
+	if wrappedQuery, isWrapped := rawData["query"]; isWrapped && len(rawData) == 1 {
+		query = wrappedQuery
+		path = "query"
+	}
+	return parseElasticQuery(query, path)
+}
@@ -0,0 +1,231 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestElasticInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   `{}`,
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "with data",
+			input: `{"bool": {"must": [{"term": {"key.lowersortable": "val"}}]}}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "with query wrapper and negations",
+			input: `{"query": {"bool": {"must": [{"range": {"age": {"gt": 18, "lte": 65}}}], "must_not": [{"term": {"key": 1}}, {"exists": {"field": "deleted"}}, {"wildcard": {"name.lowersortable": "*spam*"}}, {"terms": {"tag.lowersortable": ["a", "b"]}}]}}}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "age", Operator: contract.FilterOperatorGreaterThan, Value: 18.0},
+						{Field: "age", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 65.0},
+						{Field: "key", Operator: contract.FilterOperatorNotEqual, Value: 1.0},
+						{Field: "deleted", Operator: contract.FilterOperatorIsNil},
+						{Field: "name", Operator: contract.FilterOperatorNotContains, Value: "spam"},
+						{Field: "tag", Operator: contract.FilterOperatorNotIn, Value: []any{"a", "b"}},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "with nested data",
+			input: `{"bool": {"should": [{"bool": {"must": [{"prefix": {"key.lowersortable": "val"}}, {"exists": {"field": "key2"}}]}}, {"bool": {"must": [{"wildcard": {"key3.lowersortable": {"value": "*val3"}}}, {"match_phrase": {"key4": {"query": "a b"}}}]}}, {"bool": {"must_not": [{"term": {"key5": "val5"}}]}}], "minimum_should_match": 1}}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key5", Operator: contract.FilterOperatorNotEqual, Value: "val5"},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key", Operator: contract.FilterOperatorBegins, Value: "val"},
+									{Field: "key2", Operator: contract.FilterOperatorIsNotNil},
+								},
+							},
+						},
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key3", Operator: contract.FilterOperatorEnds, Value: "val3"},
+									{Field: "key4", Operator: contract.FilterOperatorMatchPhrase, Value: "a b"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "with value or nil",
+			input: `{"bool": {"must": [{"bool": {"should": [{"range": {"key": {"gte": 5}}}, {"bool": {"must_not": [{"exists": {"field": "key"}}]}}]}}, {"term": {"active": true}}]}}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 5.0},
+						{Field: "active", Operator: contract.FilterOperatorEqual, Value: true},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "with should next to must",
+			input: `{"bool": {"filter": {"term": {"a": 1}}, "should": [{"term": {"b": 2}}, {"term": {"c": 3}}], "minimum_should_match": "1"}}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorEqual, Value: 1.0},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "b", Operator: contract.FilterOperatorEqual, Value: 2.0},
+									{Field: "c", Operator: contract.FilterOperatorEqual, Value: 3.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - unsupported query clause",
+			input:   `{"bool": {"must": [{"term": {"a": 1}}, {"match": {"title": "foo"}}]}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "bool.must.1.match", Clause: "match", Error: "unsupported query clause"}),
+		},
+		{
+			name:    "invalid input - unsupported bool parameter",
+			input:   `{"bool": {"must": [{"term": {"a": 1}}], "boost": 2}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "bool.boost", Clause: "bool", Error: "unsupported bool parameter"}),
+		},
+		{
+			name:    "invalid input - unsupported range parameter",
+			input:   `{"query": {"range": {"created": {"gte": "now-1d", "format": "date"}}}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "query.range.created.format", Clause: "range", Error: "unsupported range parameter"}),
+		},
+		{
+			name:    "invalid input - unsupported wildcard pattern",
+			input:   `{"wildcard": {"key.lowersortable": "a*b"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "wildcard.key.lowersortable", Clause: "wildcard", Error: `unsupported wildcard pattern "a*b"`}),
+		},
+		{
+			name:    "invalid input - wildcard without a value",
+			input:   `{"wildcard": {"f": "*"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "wildcard.f", Clause: "wildcard", Error: `unsupported wildcard pattern "*"`}),
+		},
+		{
+			name:    "invalid input - negated prefix",
+			input:   `{"bool": {"must_not": [{"prefix": {"key": "a"}}]}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "bool.must_not.0", Error: `operator "begins" can't be negated`}),
+		},
+		{
+			name:    "invalid input - optional should clauses",
+			input:   `{"bool": {"must": [{"term": {"a": 1}}], "should": [{"term": {"b": 2}}]}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "bool.should", Clause: "bool", Error: "should clauses combined with must clauses require minimum_should_match of 1"}),
+		},
+		{
+			name:    "invalid input - minimum should match",
+			input:   `{"bool": {"should": [{"term": {"a": 1}}, {"term": {"b": 2}}], "minimum_should_match": 2}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Path: "bool.minimum_should_match", Clause: "bool", Error: "only minimum_should_match of 1 is supported"}),
+		},
+		{
+			name:    "invalid input - multiple clauses in one query",
+			input:   `{"term": {"a": 1}, "exists": {"field": "b"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, ElasticUnsupportedClause{Error: "query must be an object with exactly one clause"}),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := ElasticInputTransformer{}
+			input, err := NewElasticInputFromJson([]byte(tt.input))
+			if err != nil {
+				t1.Fatalf("NewElasticInputFromJson() error = %v", err)
+			}
+			got, transformErr := t.Transform(input)
+			if !reflect.DeepEqual(transformErr, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", transformErr, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElasticInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   map[string]any
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   nil,
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   map[string]any{"term": map[string]any{"key": "val"}},
+			want:    []byte(`{"term":{"key":"val"}}`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType(tt.input, &ElasticInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
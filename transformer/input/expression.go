@@ -0,0 +1,360 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const (
+	expressionTokenWord tokenKind = iota + 1
+	expressionTokenString
+	expressionTokenQuotedField
+	expressionTokenOperator
+	expressionTokenOpenParenthesis
+	expressionTokenCloseParenthesis
+	expressionTokenOpenBracket
+	expressionTokenCloseBracket
+	expressionTokenComma
+)
+
+// longer operators need to go first so that they are matched greedily
+var expressionOperatorSymbols = []string{">=?", "<=?", "!=", ">=", "<=", "!~", "~~", "^=", "$=", "=", ">", "<", "~"}
+
+var expressionOperators = map[string]contract.FilterOperator{
+	"=":   contract.FilterOperatorEqual,
+	"!=":  contract.FilterOperatorNotEqual,
+	">":   contract.FilterOperatorGreaterThan,
+	">=":  contract.FilterOperatorGreaterThanOrEqual,
+	">=?": contract.FilterOperatorGreaterThanOrEqualOrNil,
+	"<":   contract.FilterOperatorLowerThan,
+	"<=":  contract.FilterOperatorLowerThanOrEqual,
+	"<=?": contract.FilterOperatorLowerThanOrEqualOrNil,
+	"^=":  contract.FilterOperatorBegins,
+	"~":   contract.FilterOperatorContains,
+	"!~":  contract.FilterOperatorNotContains,
+	"$=":  contract.FilterOperatorEnds,
+	"~~":  contract.FilterOperatorMatchPhrase,
+}
+
+var expressionKeywords = []string{"AND", "OR", "NOT", "IN", "IS", "NULL", "EMPTY"}
+
+func isExpressionWordCharacter(character rune) bool {
+	return unicode.IsLetter(character) || unicode.IsDigit(character) || strings.ContainsRune("_.-:+/", character)
+}
+
+func lexExpression(rawData string) ([]syntaxToken, *contract.Error) {
+	var tokens []syntaxToken
+	runes := []rune(rawData)
+	position := 0
+	punctuation := map[rune]tokenKind{
+		'(': expressionTokenOpenParenthesis,
+		')': expressionTokenCloseParenthesis,
+		'[': expressionTokenOpenBracket,
+		']': expressionTokenCloseBracket,
+		',': expressionTokenComma,
+	}
+lexing:
+	for position < len(runes) {
+		character := runes[position]
+		start := position
+		if unicode.IsSpace(character) {
+			position++
+			continue
+		}
+		if kind, isPunctuation := punctuation[character]; isPunctuation {
+			tokens = append(tokens, syntaxToken{kind: kind, value: string(character), column: start + 1})
+			position++
+			continue
+		}
+		if character == '"' || character == '\'' || character == '`' {
+			var value strings.Builder
+			position++
+			for position < len(runes) {
+				if runes[position] == '\\' && position+1 < len(runes) {
+					value.WriteRune(runes[position+1])
+					position += 2
+					continue
+				}
+				if runes[position] == character {
+					position++
+					kind := expressionTokenString
+					if character == '`' {
+						kind = expressionTokenQuotedField
+					}
+					tokens = append(tokens, syntaxToken{kind: kind, value: value.String(), column: start + 1})
+					continue lexing
+				}
+				value.WriteRune(runes[position])
+				position++
+			}
+			return nil, newSyntaxError("unterminated string", start+1)
+		}
+		for _, symbol := range expressionOperatorSymbols {
+			if strings.HasPrefix(string(runes[position:]), symbol) {
+				tokens = append(tokens, syntaxToken{kind: expressionTokenOperator, value: symbol, column: start + 1})
+				position += len([]rune(symbol))
+				continue lexing
+			}
+		}
+		for position < len(runes) && isExpressionWordCharacter(runes[position]) {
+			position++
+		}
+		if position == start {
+			return nil, newUnexpectedTokenError(string(character), start+1)
+		}
+		tokens = append(tokens, syntaxToken{kind: expressionTokenWord, value: string(runes[start:position]), column: start + 1})
+	}
+	tokens = append(tokens, syntaxToken{kind: tokenEOF, column: len(runes) + 1})
+	return tokens, nil
+}
+
+type expressionParser struct {
+	tokenCursor
+}
+
+func (p *expressionParser) isKeyword(keyword string) bool {
+	return p.peek().isKeyword(expressionTokenWord, keyword)
+}
+
+func (p *expressionParser) expectKeyword(keywords ...string) (string, *contract.Error) {
+	token := p.next()
+	for _, keyword := range keywords {
+		if token.isKeyword(expressionTokenWord, keyword) {
+			return keyword, nil
+		}
+	}
+	return "", token.unexpected()
+}
+
+// or := and ('OR' and)*
+func (p *expressionParser) parseOr() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if !p.isKeyword("OR") {
+			return combineFilters(contract.FilterLogicOr, nodes), nil
+		}
+		p.next()
+	}
+}
+
+// and := unary ('AND' unary)*
+func (p *expressionParser) parseAnd() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseUnary()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if !p.isKeyword("AND") {
+			return combineFilters(contract.FilterLogicAnd, nodes), nil
+		}
+		p.next()
+	}
+}
+
+// unary := 'NOT' unary | '(' or ')' | condition
+func (p *expressionParser) parseUnary() (contract.Filters, *contract.Error) {
+	if p.isKeyword("NOT") {
+		token := p.next()
+		filters, err := p.parseUnary()
+		if err != nil {
+			return filters, err
+		}
+		negated, operator, ok := negateFilters(filters)
+		if !ok {
+			return filters, newSyntaxError(fmt.Sprintf("operator %q can't be negated", operator), token.column)
+		}
+		return negated, nil
+	}
+	if p.peek().kind == expressionTokenOpenParenthesis {
+		p.next()
+		filters, err := p.parseOr()
+		if err != nil {
+			return filters, err
+		}
+		_, err = p.expect(expressionTokenCloseParenthesis)
+		return filters, err
+	}
+	return p.parseCondition()
+}
+
+// condition := field (operator value | 'IS' ['NOT'] ('NULL' | 'EMPTY') | ['NOT'] 'IN' list)
+func (p *expressionParser) parseCondition() (contract.Filters, *contract.Error) {
+	field := p.next()
+	isKeyword := field.kind == expressionTokenWord && containsFold(expressionKeywords, field.value)
+	if field.kind != expressionTokenQuotedField && (field.kind != expressionTokenWord || isKeyword) {
+		return contract.Filters{}, field.unexpected()
+	}
+	condition := contract.FilterCondition{Field: field.value}
+
+	token := p.peek()
+	switch {
+	case token.kind == expressionTokenOperator:
+		p.next()
+		condition.Operator = expressionOperators[token.value]
+		value, err := p.parseValue()
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		condition.Value = value
+		if value == nil {
+			switch condition.Operator {
+			case contract.FilterOperatorEqual:
+				condition.Operator = contract.FilterOperatorIsNil
+			case contract.FilterOperatorNotEqual:
+				condition.Operator = contract.FilterOperatorIsNotNil
+			default:
+				return contract.Filters{}, newSyntaxError("null can only be compared using = or !=", token.column)
+			}
+		}
+	case p.isKeyword("IS"):
+		p.next()
+		negated := p.isKeyword("NOT")
+		if negated {
+			p.next()
+		}
+		keyword, err := p.expectKeyword("NULL", "EMPTY")
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		condition.Operator = map[string]map[bool]contract.FilterOperator{
+			"NULL":  {false: contract.FilterOperatorIsNil, true: contract.FilterOperatorIsNotNil},
+			"EMPTY": {false: contract.FilterOperatorIsEmpty, true: contract.FilterOperatorIsNotEmpty},
+		}[keyword][negated]
+	case p.isKeyword("IN") || p.isKeyword("NOT"):
+		condition.Operator = contract.FilterOperatorIn
+		if p.isKeyword("NOT") {
+			p.next()
+			condition.Operator = contract.FilterOperatorNotIn
+		}
+		if _, err := p.expectKeyword("IN"); err != nil {
+			return contract.Filters{}, err
+		}
+		values, err := p.parseList()
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		condition.Value = values
+	default:
+		return contract.Filters{}, token.unexpected()
+	}
+	return newConditionFilters(condition), nil
+}
+
+// list := '(' value (',' value)* ')' | '[' value (',' value)* ']'
+func (p *expressionParser) parseList() ([]any, *contract.Error) {
+	opening := p.next()
+	closingKind := expressionTokenCloseParenthesis
+	if opening.kind == expressionTokenOpenBracket {
+		closingKind = expressionTokenCloseBracket
+	} else if opening.kind != expressionTokenOpenParenthesis {
+		return nil, opening.unexpected()
+	}
+	values := []any{}
+	if p.peek().kind == closingKind {
+		p.next()
+		return values, nil
+	}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		separator := p.next()
+		if separator.kind == closingKind {
+			return values, nil
+		}
+		if separator.kind != expressionTokenComma {
+			return nil, separator.unexpected()
+		}
+	}
+}
+
+// value := string | number | 'true' | 'false' | 'null' | word
+func (p *expressionParser) parseValue() (any, *contract.Error) {
+	token := p.next()
+	switch token.kind {
+	case expressionTokenString:
+		return token.value, nil
+	case expressionTokenWord:
+		// like the keywords, the literals are case-insensitive
+		switch {
+		case strings.EqualFold(token.value, "NULL"):
+			return nil, nil
+		case strings.EqualFold(token.value, "TRUE"):
+			return true, nil
+		case strings.EqualFold(token.value, "FALSE"):
+			return false, nil
+		case containsFold(expressionKeywords, token.value):
+			return nil, token.unexpected()
+		}
+		return parseLiteral(token.value), nil
+	}
+	return nil, token.unexpected()
+}
+
+func containsFold(values []string, value string) bool {
+	for _, item := range values {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+type ExpressionInput struct {
+	contract.InputOutputType[string]
+}
+
+func (i *ExpressionInput) GetDataString() (string, error) {
+	return i.GetData()
+}
+
+func (i *ExpressionInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == "" {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+type ExpressionInputTransformer struct {
+}
+
+func (t *ExpressionInputTransformer) Transform(input *ExpressionInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if strings.TrimSpace(rawData) == "" {
+		return filters, nil
+	}
+	tokens, transformErr := lexExpression(rawData)
+	if transformErr != nil {
+		return filters, transformErr
+	}
+	parser := expressionParser{tokenCursor{tokens: tokens}}
+	filters, transformErr = parser.parseOr()
+	if transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	if transformErr = parser.expectEnd(); transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	return filters, nil
+}
@@ -0,0 +1,229 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestExpressionInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   "  ",
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "example from the search box",
+			input: `(status = "active" OR status = "trial") AND created >= 2024-01-01 AND name ~ "acme"`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "created", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: "2024-01-01"},
+						{Field: "name", Operator: contract.FilterOperatorContains, Value: "acme"},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "status", Operator: contract.FilterOperatorEqual, Value: "active"},
+									{Field: "status", Operator: contract.FilterOperatorEqual, Value: "trial"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "precedence of and over or",
+			input: `a = 1 or b = true and c != null`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorEqual, Value: 1.0},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "b", Operator: contract.FilterOperatorEqual, Value: true},
+									{Field: "c", Operator: contract.FilterOperatorIsNotNil, Value: nil},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "case-insensitive literals",
+			input: `active = TRUE AND deleted != False AND note = NULL AND tags IN (true, "TRUE")`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "active", Operator: contract.FilterOperatorEqual, Value: true},
+						{Field: "deleted", Operator: contract.FilterOperatorNotEqual, Value: false},
+						{Field: "note", Operator: contract.FilterOperatorIsNil, Value: nil},
+						{Field: "tags", Operator: contract.FilterOperatorIn, Value: []any{true, "TRUE"}},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "all operators",
+			input: "a != 'x' AND b > 1 AND c >= -1.5 AND d >=? 2 AND e < 3 AND f <= 4 AND g <=? 5 AND h ^= \"x\" AND i !~ \"y\" AND j $= z AND k ~~ \"a b\" AND l IS NULL AND m IS NOT NULL AND n IS EMPTY AND o is not empty AND p IN (1, \"2\") AND q NOT IN [] AND `my field` = null",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorNotEqual, Value: "x"},
+						{Field: "b", Operator: contract.FilterOperatorGreaterThan, Value: 1.0},
+						{Field: "c", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: -1.5},
+						{Field: "d", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 2.0},
+						{Field: "e", Operator: contract.FilterOperatorLowerThan, Value: 3.0},
+						{Field: "f", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 4.0},
+						{Field: "g", Operator: contract.FilterOperatorLowerThanOrEqualOrNil, Value: 5.0},
+						{Field: "h", Operator: contract.FilterOperatorBegins, Value: "x"},
+						{Field: "i", Operator: contract.FilterOperatorNotContains, Value: "y"},
+						{Field: "j", Operator: contract.FilterOperatorEnds, Value: "z"},
+						{Field: "k", Operator: contract.FilterOperatorMatchPhrase, Value: "a b"},
+						{Field: "l", Operator: contract.FilterOperatorIsNil},
+						{Field: "m", Operator: contract.FilterOperatorIsNotNil},
+						{Field: "n", Operator: contract.FilterOperatorIsEmpty},
+						{Field: "o", Operator: contract.FilterOperatorIsNotEmpty},
+						{Field: "p", Operator: contract.FilterOperatorIn, Value: []any{1.0, "2"}},
+						{Field: "q", Operator: contract.FilterOperatorNotIn, Value: []any{}},
+						{Field: "my field", Operator: contract.FilterOperatorIsNil},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "negation",
+			input: `NOT (a = 1 OR b IN (2, 3))`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorNotEqual, Value: 1.0},
+						{Field: "b", Operator: contract.FilterOperatorNotIn, Value: []any{2.0, 3.0}},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - missing value",
+			input:   `status = AND a = 1`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token "AND" at column 10`),
+		},
+		{
+			name:    "invalid input - unexpected token",
+			input:   `status = "active")`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token ")" at column 18`),
+		},
+		{
+			name:    "invalid input - missing operator",
+			input:   `status "active"`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token "active" at column 8`),
+		},
+		{
+			name:    "invalid input - keyword as field",
+			input:   `AND = 1`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token "AND" at column 1`),
+		},
+		{
+			name:    "invalid input - IS without NULL",
+			input:   `a IS 1`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token "1" at column 6`),
+		},
+		{
+			name:    "invalid input - null comparison",
+			input:   `a > null`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `null can only be compared using = or != at column 3`),
+		},
+		{
+			name:    "invalid input - unterminated string",
+			input:   `a = "x`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unterminated string at column 5`),
+		},
+		{
+			name:    "invalid input - unexpected character",
+			input:   `a = 1 & b = 2`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token "&" at column 7`),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := ExpressionInputTransformer{}
+			input, _ := contract.NewInputOutputType(tt.input, &ExpressionInput{})
+			got, err := t.Transform(input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   `name ~ "acme"`,
+			want:    []byte(`"name ~ \"acme\""`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType(tt.input, &ExpressionInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
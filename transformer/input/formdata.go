@@ -1,25 +1,97 @@
 package input
 
-import "github.com/wernerdweight/filter-transformer-go/transformer/contract"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const (
+	FormDataDefaultRootKey   = "filter"
+	FormDataMaxMemory        = 32 << 20
+	contentTypeUrlEncoded    = "application/x-www-form-urlencoded"
+	contentTypeMultipartForm = "multipart/form-data"
+)
 
 type FormDataInput struct {
-	contract.InputOutputType[interface{}] // TODO: multipart form data
+	contract.InputOutputType[map[string][]string]
 }
 
 func (i *FormDataInput) GetDataString() (string, error) {
-	// TODO: implement
-	return "", nil
+	rawData, err := i.GetData()
+	if err != nil {
+		return "", err
+	}
+	return url.Values(rawData).Encode(), nil
 }
 
 func (i *FormDataInput) GetDataJson() ([]byte, error) {
-	// TODO: implement
-	return nil, nil
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	return marshalBracketTree(rawData)
+}
+
+func NewFormDataInputFromBody(contentType string, body io.Reader) (*FormDataInput, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string][]string
+	switch mediaType {
+	case contentTypeUrlEncoded:
+		rawData, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		data, err = url.ParseQuery(string(rawData))
+		if err != nil {
+			return nil, err
+		}
+	case contentTypeMultipartForm:
+		boundary, ok := params["boundary"]
+		if !ok {
+			return nil, errors.New("missing multipart boundary")
+		}
+		form, err := multipart.NewReader(body, boundary).ReadForm(FormDataMaxMemory)
+		if err != nil {
+			return nil, err
+		}
+		defer form.RemoveAll()
+		data = form.Value
+	default:
+		return nil, fmt.Errorf("unsupported content type %s", mediaType)
+	}
+	return contract.NewInputOutputType(data, &FormDataInput{})
+}
+
+func NewFormDataInputFromRequest(request *http.Request) (*FormDataInput, error) {
+	return NewFormDataInputFromBody(request.Header.Get("Content-Type"), request.Body)
 }
 
 type FormDataInputTransformer struct {
+	RootKey string
 }
 
-func (t *FormDataInputTransformer) Transform(input FormDataInput) (contract.Filters, *contract.Error) {
-	// TODO: implement
-	return contract.Filters{}, nil
+func (t *FormDataInputTransformer) Transform(input *FormDataInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if rawData == nil {
+		return filters, nil
+	}
+	rootKey := t.RootKey
+	if rootKey == "" {
+		rootKey = FormDataDefaultRootKey
+	}
+	return transformBracketTree(rawData, rootKey)
 }
@@ -0,0 +1,393 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testInputFormData0, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[logic]":                   {"and"},
+	"filter[conditions][0][field]":    {"key"},
+	"filter[conditions][0][operator]": {"eq"},
+	"filter[conditions][0][value]":    {"val"},
+}, &FormDataInput{})
+var testInputFormData1, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[logic]":                                  {"and"},
+	"filter[conditions][0][logic]":                   {"or"},
+	"filter[conditions][0][conditions][0][field]":    {"key"},
+	"filter[conditions][0][conditions][0][operator]": {"eq"},
+	"filter[conditions][0][conditions][0][value]":    {"val"},
+	"filter[conditions][0][conditions][1][field]":    {"key2"},
+	"filter[conditions][0][conditions][1][operator]": {"neq"},
+	"filter[conditions][0][conditions][1][value]":    {"val2"},
+}, &FormDataInput{})
+var testInputFormData2, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[logic]":                   {"or"},
+	"filter[conditions][7][field]":    {"key2"},
+	"filter[conditions][7][operator]": {"not-null"},
+	"filter[conditions][3][field]":    {"key"},
+	"filter[conditions][3][operator]": {"gte"},
+	"filter[conditions][3][value]":    {"123"},
+	"other":                           {"ignored"},
+}, &FormDataInput{})
+var testInputFormData3, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[conditions][0][field]":    {"key"},
+	"filter[conditions][0][operator]": {"in"},
+	"filter[conditions][0][value][]":  {"val", "val2"},
+}, &FormDataInput{})
+var testInputFormData4, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[conditions][0][field]":    {"key"},
+	"filter[conditions][0][operator]": {"in"},
+	"filter[conditions][0][value]":    {"val, val2"},
+}, &FormDataInput{})
+var invalidInputFormData0, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[conditions][0][field":     {"key"},
+	"filter[conditions][0][operator]": {"eq"},
+}, &FormDataInput{})
+var invalidInputFormData1, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[conditions][0][field]":        {"key"},
+	"filter[conditions][0][field][inner]": {"key"},
+}, &FormDataInput{})
+var invalidInputFormData2, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[conditions][][field]": {"key"},
+}, &FormDataInput{})
+var invalidInputFormData3, _ = contract.NewInputOutputType(map[string][]string{
+	"filter[logic]": {"and"},
+}, &FormDataInput{})
+
+func TestFormDataInputTransformer_Transform(t1 *testing.T) {
+	type args struct {
+		input *FormDataInput
+	}
+	tests := []struct {
+		name        string
+		transformer FormDataInputTransformer
+		args        args
+		want        contract.Filters
+		wantErr     *contract.Error
+	}{
+		{
+			name: "empty input",
+			args: args{
+				input: &FormDataInput{},
+			},
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name: "input with data",
+			args: args{
+				input: testInputFormData0,
+			},
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{
+							Field:    "key",
+							Operator: contract.FilterOperatorEqual,
+							Value:    "val",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "input with nested data",
+			args: args{
+				input: testInputFormData1,
+			},
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{
+										Field:    "key",
+										Operator: contract.FilterOperatorEqual,
+										Value:    "val",
+									},
+									{
+										Field:    "key2",
+										Operator: contract.FilterOperatorNotEqual,
+										Value:    "val2",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "input with sparse unordered indices",
+			args: args{
+				input: testInputFormData2,
+			},
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{
+							Field:    "key",
+							Operator: contract.FilterOperatorGreaterThanOrEqual,
+							Value:    "123",
+						},
+						{
+							Field:    "key2",
+							Operator: contract.FilterOperatorIsNotNil,
+							Value:    nil,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "input with array value",
+			args: args{
+				input: testInputFormData3,
+			},
+			want: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{
+							Field:    "key",
+							Operator: contract.FilterOperatorIn,
+							Value:    []any{"val", "val2"},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "input with comma separated value",
+			args: args{
+				input: testInputFormData4,
+			},
+			want: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{
+							Field:    "key",
+							Operator: contract.FilterOperatorIn,
+							Value:    []string{"val", "val2"},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "input with custom root key",
+			transformer: FormDataInputTransformer{RootKey: "where"},
+			args: args{
+				input: testInputFormData0,
+			},
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name: "invalid input - unclosed bracket",
+			args: args{
+				input: invalidInputFormData0,
+			},
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter[conditions][0][field: unclosed bracket"),
+		},
+		{
+			name: "invalid input - conflicting keys",
+			args: args{
+				input: invalidInputFormData1,
+			},
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter[conditions][0][field][inner]: key conflicts with another key"),
+		},
+		{
+			name: "invalid input - empty index in the middle",
+			args: args{
+				input: invalidInputFormData2,
+			},
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter[conditions][][field]: empty index is only allowed at the end of the key"),
+		},
+		{
+			name: "input without conditions",
+			args: args{
+				input: invalidInputFormData3,
+			},
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			got, err := tt.transformer.Transform(tt.args.input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFormDataInputFromBody(t *testing.T) {
+	multipartBody := strings.Join([]string{
+		"--boundary",
+		`Content-Disposition: form-data; name="filter[logic]"`,
+		"",
+		"and",
+		"--boundary",
+		`Content-Disposition: form-data; name="filter[conditions][0][field]"`,
+		"",
+		"key",
+		"--boundary--",
+		"",
+	}, "\r\n")
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        map[string][]string
+		wantErr     bool
+	}{
+		{
+			name:        "url encoded",
+			contentType: "application/x-www-form-urlencoded",
+			body:        url.Values{"filter[logic]": {"and"}, "filter[conditions][0][field]": {"key"}}.Encode(),
+			want:        map[string][]string{"filter[logic]": {"and"}, "filter[conditions][0][field]": {"key"}},
+			wantErr:     false,
+		},
+		{
+			name:        "multipart",
+			contentType: "multipart/form-data; boundary=boundary",
+			body:        multipartBody,
+			want:        map[string][]string{"filter[logic]": {"and"}, "filter[conditions][0][field]": {"key"}},
+			wantErr:     false,
+		},
+		{
+			name:        "multipart without boundary",
+			contentType: "multipart/form-data",
+			body:        multipartBody,
+			want:        nil,
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported content type",
+			contentType: "application/json",
+			body:        `{"logic": "and"}`,
+			want:        nil,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFormDataInputFromBody(tt.contentType, strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewFormDataInputFromBody() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			data, _ := got.GetData()
+			if !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("NewFormDataInputFromBody() got = %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormDataInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name          string
+		formDataInput FormDataInput
+		want          []byte
+		wantErr       bool
+	}{
+		{
+			name:          "empty input",
+			formDataInput: FormDataInput{},
+			want:          nil,
+			wantErr:       false,
+		},
+		{
+			name:          "input with data",
+			formDataInput: *testInputFormData0,
+			want:          []byte(`{"filter":{"conditions":[{"field":"key","operator":"eq","value":"val"}],"logic":"and"}}`),
+			wantErr:       false,
+		},
+		{
+			name:          "input with array value",
+			formDataInput: *testInputFormData3,
+			want:          []byte(`{"filter":{"conditions":[{"field":"key","operator":"in","value":["val","val2"]}]}}`),
+			wantErr:       false,
+		},
+		{
+			name:          "invalid input",
+			formDataInput: *invalidInputFormData0,
+			want:          nil,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.formDataInput.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormDataInput_GetDataString(t *testing.T) {
+	tests := []struct {
+		name          string
+		formDataInput FormDataInput
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "empty input",
+			formDataInput: FormDataInput{},
+			want:          "",
+			wantErr:       false,
+		},
+		{
+			name:          "input with data",
+			formDataInput: *testInputFormData0,
+			want:          "filter%5Bconditions%5D%5B0%5D%5Bfield%5D=key&filter%5Bconditions%5D%5B0%5D%5Boperator%5D=eq&filter%5Bconditions%5D%5B0%5D%5Bvalue%5D=val&filter%5Blogic%5D=and",
+			wantErr:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.formDataInput.GetDataString()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetDataString() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
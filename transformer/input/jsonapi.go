@@ -0,0 +1,144 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const JSONAPIDefaultRootKey = "filter"
+
+var (
+	errUnexpectedFilterKey = errors.New("expected filter[field] or filter[field][operator]")
+	errEmptyFilterList     = errors.New("expected at least one value")
+)
+
+type JSONAPIInput struct {
+	contract.InputOutputType[url.Values]
+}
+
+func (i *JSONAPIInput) GetDataString() (string, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return "", err
+	}
+	return rawData.Encode(), nil
+}
+
+func (i *JSONAPIInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	return marshalBracketTree(rawData)
+}
+
+func NewJSONAPIInputFromRawQuery(rawQuery string) (*JSONAPIInput, error) {
+	data, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+	return contract.NewInputOutputType(data, &JSONAPIInput{})
+}
+
+type JSONAPIInputTransformer struct {
+	// RootKey defaults to JSONAPIDefaultRootKey
+	RootKey string
+}
+
+func (t *JSONAPIInputTransformer) getRootKey() string {
+	if t.RootKey == "" {
+		return JSONAPIDefaultRootKey
+	}
+	return t.RootKey
+}
+
+// parseJSONAPIValue converts numbers and booleans like JSON would, `null` stays a string (use the nil operator instead)
+func parseJSONAPIValue(value string) any {
+	if literal := parseLiteral(value); literal != nil {
+		return literal
+	}
+	return value
+}
+
+// Transform reads `filter[field]=value` (eq) and `filter[field][operator]=value` keys into conditions joined using AND;
+// repeated keys of in/not-in are merged, repeated keys of other operators produce one condition each
+func (t *JSONAPIInputTransformer) Transform(input *JSONAPIInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	rootKey := t.getRootKey()
+	keys := make([]string, 0, len(rawData))
+	for key := range rawData {
+		if isBracketKeyUnder(key, rootKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var conditions []contract.FilterCondition
+	for _, key := range keys {
+		segments, err := splitBracketKey(key)
+		if err != nil {
+			return filters, newMalformedKeyError(key, err)
+		}
+		if segments[len(segments)-1] == "" {
+			// `filter[tags][in][]=a&filter[tags][in][]=b`
+			segments = segments[:len(segments)-1]
+		}
+		if len(segments) < 2 || len(segments) > 3 || segments[1] == "" {
+			return filters, newMalformedKeyError(key, errUnexpectedFilterKey)
+		}
+		field := segments[1]
+		operator := contract.FilterOperatorEqual
+		if len(segments) == 3 {
+			operator = contract.FilterOperator(segments[2])
+		}
+		if !slices.Contains(contract.SupportedOperators(), operator) {
+			return filters, newMalformedKeyError(key, fmt.Errorf("unsupported operator %q", operator))
+		}
+
+		values := rawData[key]
+		var conditionValues []any
+		switch operator {
+		case contract.FilterOperatorIsNil, contract.FilterOperatorIsNotNil, contract.FilterOperatorIsEmpty, contract.FilterOperatorIsNotEmpty:
+			// the value is not used (`filter[deleted_at][null]`)
+			conditionValues = []any{nil}
+		case contract.FilterOperatorIn, contract.FilterOperatorNotIn:
+			joined := strings.Join(values, ",")
+			if strings.TrimSpace(joined) == "" {
+				// `filter[tags][in]=` would become in [""]
+				return filters, newMalformedKeyError(key, errEmptyFilterList)
+			}
+			var items []any
+			for _, item := range strings.Split(joined, ",") {
+				items = append(items, parseJSONAPIValue(strings.TrimSpace(item)))
+			}
+			conditionValues = []any{items}
+		default:
+			for _, value := range values {
+				conditionValues = append(conditionValues, parseJSONAPIValue(value))
+			}
+		}
+		for _, value := range conditionValues {
+			condition, err := newJSONCondition(field, operator, value)
+			if err != nil {
+				return filters, contract.NewError(contract.InvalidInputDataStructure, err.Error())
+			}
+			conditions = append(conditions, condition)
+		}
+	}
+	if len(conditions) == 0 {
+		return filters, nil
+	}
+	filters.Logic = contract.FilterLogicAnd
+	filters.Conditions.Conditions = conditions
+	return filters, nil
+}
@@ -0,0 +1,192 @@
+package input
+
+import (
+	"fmt"
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestJSONAPIInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		rootKey string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   "page[size]=10&sort=-price",
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "example from the API",
+			input: "filter[price][gte]=10&filter[tags][in]=a,b&page[size]=10",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "price", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 10.0},
+						{Field: "tags", Operator: contract.FilterOperatorIn, Value: []any{"a", "b"}},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "implicit eq, repeated keys and null operators",
+			input: "filter[author.name]=Jane&filter[tags][not-in][]=a&filter[tags][not-in][]=b, c&filter[status][neq]=draft&filter[status][neq]=deleted&filter[deleted_at][nil]",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "author.name", Operator: contract.FilterOperatorEqual, Value: "Jane"},
+						{Field: "deleted_at", Operator: contract.FilterOperatorIsNil},
+						{Field: "status", Operator: contract.FilterOperatorNotEqual, Value: "draft"},
+						{Field: "status", Operator: contract.FilterOperatorNotEqual, Value: "deleted"},
+						{Field: "tags", Operator: contract.FilterOperatorNotIn, Value: []any{"a", "b", "c"}},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "custom root key",
+			rootKey: "where",
+			input:   "where[price][lt]=5&filter[price][gt]=1",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "price", Operator: contract.FilterOperatorLowerThan, Value: 5.0},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "numbers and booleans",
+			input: "filter[active]=true&filter[id][in]=1,2&filter[name]=null&filter[price][gt]=10.5&filter[sku][not-in]=007,x",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "active", Operator: contract.FilterOperatorEqual, Value: true},
+						{Field: "id", Operator: contract.FilterOperatorIn, Value: []any{1.0, 2.0}},
+						{Field: "name", Operator: contract.FilterOperatorEqual, Value: "null"},
+						{Field: "price", Operator: contract.FilterOperatorGreaterThan, Value: 10.5},
+						{Field: "sku", Operator: contract.FilterOperatorNotIn, Value: []any{"007", "x"}},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - empty list",
+			input:   "filter[tags][in]=",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter[tags][in]: expected at least one value"),
+		},
+		{
+			name:    "invalid input - unsupported operator",
+			input:   "filter[price][gtx]=10",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `filter[price][gtx]: unsupported operator "gtx"`),
+		},
+		{
+			name:    "invalid input - missing field",
+			input:   "filter=10",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter: expected filter[field] or filter[field][operator]"),
+		},
+		{
+			name:    "invalid input - too deep",
+			input:   "filter[author][name][eq]=Jane",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter[author][name][eq]: expected filter[field] or filter[field][operator]"),
+		},
+		{
+			name:    "invalid input - malformed key",
+			input:   "filter[price[gte]=10",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter[price[gte]: unexpected bracket"),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := JSONAPIInputTransformer{RootKey: tt.rootKey}
+			input, err := NewJSONAPIInputFromRawQuery(tt.input)
+			if err != nil {
+				t1.Fatalf("NewJSONAPIInputFromRawQuery() error = %v", err)
+			}
+			got, transformErr := t.Transform(input)
+			if !reflect.DeepEqual(transformErr, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", transformErr, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONAPIInputTransformer_TransformSupportedOperators(t1 *testing.T) {
+	for _, operator := range contract.SupportedOperators() {
+		t1.Run(string(operator), func(t1 *testing.T) {
+			t := JSONAPIInputTransformer{}
+			input, _ := NewJSONAPIInputFromRawQuery(fmt.Sprintf("filter[key][%s]=val", operator))
+			got, err := t.Transform(input)
+			if err != nil {
+				t1.Fatalf("Transform() error = %v", err)
+			}
+			want := contract.FilterCondition{Field: "key", Operator: operator, Value: "val"}
+			switch operator {
+			case contract.FilterOperatorIsNil, contract.FilterOperatorIsNotNil, contract.FilterOperatorIsEmpty, contract.FilterOperatorIsNotEmpty:
+				want.Value = nil
+			case contract.FilterOperatorIn, contract.FilterOperatorNotIn:
+				want.Value = []any{"val"}
+			}
+			if len(got.Conditions.Conditions) != 1 || !reflect.DeepEqual(got.Conditions.Conditions[0], want) {
+				t1.Errorf("Transform() got = %v, want %v", got.Conditions.Conditions, want)
+			}
+		})
+	}
+}
+
+func TestJSONAPIInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    []byte(`{}`),
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   "filter[price][gte]=10",
+			want:    []byte(`{"filter":{"price":{"gte":"10"}}}`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := NewJSONAPIInputFromRawQuery(tt.input)
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+var mongoComparisonOperators = map[string]contract.FilterOperator{
+	"$eq":  contract.FilterOperatorEqual,
+	"$ne":  contract.FilterOperatorNotEqual,
+	"$gt":  contract.FilterOperatorGreaterThan,
+	"$gte": contract.FilterOperatorGreaterThanOrEqual,
+	"$lt":  contract.FilterOperatorLowerThan,
+	"$lte": contract.FilterOperatorLowerThanOrEqual,
+	"$in":  contract.FilterOperatorIn,
+	"$nin": contract.FilterOperatorNotIn,
+}
+
+const mongoRegexMetaCharacters = `\.*+?()[]{}|^$`
+
+// MongoUnsupportedOperator is the payload of the error returned for a part of the document that can't be converted to filters
+type MongoUnsupportedOperator struct {
+	Path     string
+	Operator string
+	Error    string
+}
+
+func newMongoOperatorError(path string, operator string, message string) *contract.Error {
+	return contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{
+		Path:     path,
+		Operator: operator,
+		Error:    message,
+	})
+}
+
+func joinMongoPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+// sortedMongoKeys makes the order of the conditions stable as Go maps are not ordered
+func sortedMongoKeys(document map[string]any) []string {
+	keys := make([]string, 0, len(document))
+	for key := range document {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func mongoDocumentList(raw any) ([]any, bool) {
+	switch typedRaw := raw.(type) {
+	case []any:
+		return typedRaw, len(typedRaw) > 0
+	case []map[string]any:
+		documents := make([]any, 0, len(typedRaw))
+		for _, document := range typedRaw {
+			documents = append(documents, document)
+		}
+		return documents, len(documents) > 0
+	}
+	return nil, false
+}
+
+// parseMongoDocument joins all fields and logical operators of the document using AND
+func parseMongoDocument(raw any, path string) (contract.Filters, *contract.Error) {
+	document, isDocument := raw.(map[string]any)
+	if !isDocument {
+		return contract.Filters{}, newMongoOperatorError(path, "", "expected a document")
+	}
+	var nodes []contract.Filters
+	for _, key := range sortedMongoKeys(document) {
+		keyPath := joinMongoPath(path, key)
+		var node contract.Filters
+		var err *contract.Error
+		switch key {
+		case "$and", "$or", "$nor":
+			node, err = parseMongoLogicalOperator(key, document[key], keyPath)
+		default:
+			if strings.HasPrefix(key, "$") {
+				return contract.Filters{}, newMongoOperatorError(keyPath, key, "unsupported operator")
+			}
+			node, err = parseMongoField(key, document[key], keyPath)
+		}
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 0 {
+		return contract.Filters{}, nil
+	}
+	return combineFilters(contract.FilterLogicAnd, nodes), nil
+}
+
+func parseMongoLogicalOperator(operator string, raw any, path string) (contract.Filters, *contract.Error) {
+	documents, ok := mongoDocumentList(raw)
+	if !ok {
+		return contract.Filters{}, newMongoOperatorError(path, operator, "expected a non-empty array of documents")
+	}
+	var nodes []contract.Filters
+	for index, document := range documents {
+		node, err := parseMongoDocument(document, joinMongoPath(path, strconv.Itoa(index)))
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		if !node.IsEmpty() {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return contract.Filters{}, newMongoOperatorError(path, operator, "expected a non-empty array of documents")
+	}
+	if operator == "$and" {
+		return combineFilters(contract.FilterLogicAnd, nodes), nil
+	}
+	filters := combineFilters(contract.FilterLogicOr, nodes)
+	if operator == "$nor" {
+		negated, negatedOperator, ok := negateFilters(filters)
+		if !ok {
+			return contract.Filters{}, newMongoOperatorError(path, operator, fmt.Sprintf("operator %q can't be negated", negatedOperator))
+		}
+		return negated, nil
+	}
+	return filters, nil
+}
+
+func isMongoOperatorDocument(raw any) (map[string]any, bool) {
+	document, isDocument := raw.(map[string]any)
+	if !isDocument || len(document) == 0 {
+		return nil, false
+	}
+	for key := range document {
+		if !strings.HasPrefix(key, "$") {
+			return nil, false
+		}
+	}
+	return document, true
+}
+
+// parseMongoField converts `{"field": value}` and `{"field": {"$operator": value, ...}}`, multiple operators are joined using AND
+func parseMongoField(field string, raw any, path string) (contract.Filters, *contract.Error) {
+	operators, isOperatorDocument := isMongoOperatorDocument(raw)
+	if !isOperatorDocument {
+		condition, err := newMongoCondition(field, "$eq", raw, path)
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		return newConditionFilters(condition), nil
+	}
+	var nodes []contract.Filters
+	for _, operator := range sortedMongoKeys(operators) {
+		operatorPath := joinMongoPath(path, operator)
+		switch operator {
+		case "$options":
+			// no option is accepted (not even i) as the outputs don't have a case-insensitive variant of every operator
+			if options, isString := operators[operator].(string); !isString || options != "" {
+				return contract.Filters{}, newMongoOperatorError(operatorPath, operator, fmt.Sprintf("unsupported options %v", operators[operator]))
+			}
+			if _, hasRegex := operators["$regex"]; !hasRegex {
+				return contract.Filters{}, newMongoOperatorError(operatorPath, operator, "$options can only be used with $regex")
+			}
+			continue
+		case "$not":
+			if _, isOperatorDocument := isMongoOperatorDocument(operators[operator]); !isOperatorDocument {
+				return contract.Filters{}, newMongoOperatorError(operatorPath, operator, "expected an operator document")
+			}
+			node, err := parseMongoField(field, operators[operator], operatorPath)
+			if err != nil {
+				return contract.Filters{}, err
+			}
+			negated, negatedOperator, ok := negateFilters(node)
+			if !ok {
+				return contract.Filters{}, newMongoOperatorError(operatorPath, operator, fmt.Sprintf("operator %q can't be negated", negatedOperator))
+			}
+			nodes = append(nodes, negated)
+			continue
+		}
+		condition, err := newMongoCondition(field, operator, operators[operator], operatorPath)
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		nodes = append(nodes, newConditionFilters(condition))
+	}
+	return combineFilters(contract.FilterLogicAnd, nodes), nil
+}
+
+func newMongoCondition(field string, operator string, value any, path string) (contract.FilterCondition, *contract.Error) {
+	condition := contract.FilterCondition{Field: field, Value: value}
+	switch operator {
+	case "$exists":
+		exists, isBool := value.(bool)
+		if !isBool {
+			return condition, newMongoOperatorError(path, operator, "expected true or false")
+		}
+		condition.Operator, condition.Value = contract.FilterOperatorIsNil, nil
+		if exists {
+			condition.Operator = contract.FilterOperatorIsNotNil
+		}
+		return condition, nil
+	case "$regex":
+		pattern, isString := value.(string)
+		if !isString {
+			return condition, newMongoOperatorError(path, operator, "expected a string pattern")
+		}
+		return newMongoRegexCondition(condition, pattern, path)
+	case "$in", "$nin":
+		values, isArray := value.([]any)
+		if !isArray {
+			return condition, newMongoOperatorError(path, operator, "expected an array of values")
+		}
+		condition.Operator, condition.Value = mongoComparisonOperators[operator], values
+		return condition, nil
+	}
+	filterOperator, isSupported := mongoComparisonOperators[operator]
+	if !isSupported {
+		return condition, newMongoOperatorError(path, operator, "unsupported operator")
+	}
+	switch value.(type) {
+	case map[string]any, []any:
+		return condition, newMongoOperatorError(path, operator, "comparing documents and arrays is not supported")
+	}
+	condition.Operator = filterOperator
+	if value == nil {
+		// `{"field": null}` matches documents where the field is null or missing
+		switch filterOperator {
+		case contract.FilterOperatorEqual:
+			condition.Operator = contract.FilterOperatorIsNil
+		case contract.FilterOperatorNotEqual:
+			condition.Operator = contract.FilterOperatorIsNotNil
+		default:
+			return condition, newMongoOperatorError(path, operator, "null can only be compared using $eq or $ne")
+		}
+	}
+	return condition, nil
+}
+
+// newMongoRegexCondition converts literal patterns anchored using `^` and/or `$` to eq, begins, ends and contains
+func newMongoRegexCondition(condition contract.FilterCondition, pattern string, path string) (contract.FilterCondition, *contract.Error) {
+	runes := []rune(pattern)
+	anchoredStart := len(runes) > 0 && runes[0] == '^'
+	if anchoredStart {
+		runes = runes[1:]
+	}
+	anchoredEnd := len(runes) > 0 && runes[len(runes)-1] == '$' && (len(runes) < 2 || runes[len(runes)-2] != '\\')
+	if anchoredEnd {
+		runes = runes[:len(runes)-1]
+	}
+	var literal strings.Builder
+	for index := 0; index < len(runes); index++ {
+		character := runes[index]
+		if character == '\\' && index+1 < len(runes) && strings.ContainsRune(mongoRegexMetaCharacters, runes[index+1]) {
+			index++
+			literal.WriteRune(runes[index])
+			continue
+		}
+		if strings.ContainsRune(mongoRegexMetaCharacters, character) {
+			return condition, newMongoOperatorError(path, "$regex", fmt.Sprintf("unsupported pattern %q", pattern))
+		}
+		literal.WriteRune(character)
+	}
+	condition.Value = literal.String()
+	switch {
+	case anchoredStart && anchoredEnd:
+		condition.Operator = contract.FilterOperatorEqual
+	case anchoredStart:
+		condition.Operator = contract.FilterOperatorBegins
+	case anchoredEnd:
+		condition.Operator = contract.FilterOperatorEnds
+	default:
+		condition.Operator = contract.FilterOperatorContains
+	}
+	return condition, nil
+}
+
+type MongoInput struct {
+	contract.InputOutputType[map[string]any]
+}
+
+func (i *MongoInput) GetDataString() (string, error) {
+	rawData, err := i.GetDataJson()
+	if err != nil {
+		return "", err
+	}
+	return string(rawData), nil
+}
+
+func (i *MongoInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == nil {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+func NewMongoInputFromJson(data []byte) (*MongoInput, error) {
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return contract.NewInputOutputType(document, &MongoInput{})
+}
+
+type MongoInputTransformer struct {
+}
+
+func (t *MongoInputTransformer) Transform(input *MongoInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if len(rawData) == 0 {
+		return filters, nil
+	}
+	return parseMongoDocument(rawData, "")
+}
@@ -0,0 +1,199 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestMongoInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   `{}`,
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "example from a client",
+			input: `{"$or":[{"age":{"$gte":18}},{"name":{"$regex":"^a"}}]}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "age", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 18.0},
+						{Field: "name", Operator: contract.FilterOperatorBegins, Value: "a"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "all operators",
+			input: `{"a": "x", "b": {"$eq": 1, "$ne": 2}, "c": {"$gt": 1, "$lt": 5}, "d": {"$gte": 1, "$lte": 5}, "e": {"$in": ["x", 1]}, "f": {"$nin": []}, "g": {"$exists": true}, "h": {"$exists": false}, "i": null, "j": {"$ne": null}, "k": {"$regex": "a\\.b", "$options": ""}, "l": {"$regex": "b$"}, "m": {"$regex": "^c$"}, "n.o": true}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorEqual, Value: "x"},
+						{Field: "b", Operator: contract.FilterOperatorEqual, Value: 1.0},
+						{Field: "b", Operator: contract.FilterOperatorNotEqual, Value: 2.0},
+						{Field: "c", Operator: contract.FilterOperatorGreaterThan, Value: 1.0},
+						{Field: "c", Operator: contract.FilterOperatorLowerThan, Value: 5.0},
+						{Field: "d", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 1.0},
+						{Field: "d", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 5.0},
+						{Field: "e", Operator: contract.FilterOperatorIn, Value: []any{"x", 1.0}},
+						{Field: "f", Operator: contract.FilterOperatorNotIn, Value: []any{}},
+						{Field: "g", Operator: contract.FilterOperatorIsNotNil},
+						{Field: "h", Operator: contract.FilterOperatorIsNil},
+						{Field: "i", Operator: contract.FilterOperatorIsNil},
+						{Field: "j", Operator: contract.FilterOperatorIsNotNil},
+						{Field: "k", Operator: contract.FilterOperatorContains, Value: "a.b"},
+						{Field: "l", Operator: contract.FilterOperatorEnds, Value: "b"},
+						{Field: "m", Operator: contract.FilterOperatorEqual, Value: "c"},
+						{Field: "n.o", Operator: contract.FilterOperatorEqual, Value: true},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "nested logical operators",
+			input: `{"status": "active", "$and": [{"$or": [{"a": 1}, {"b": 2}]}, {"$nor": [{"c": 3}, {"d": {"$regex": "x"}}]}], "e": {"$not": {"$in": [1, 2]}}}`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "c", Operator: contract.FilterOperatorNotEqual, Value: 3.0},
+						{Field: "d", Operator: contract.FilterOperatorNotContains, Value: "x"},
+						{Field: "e", Operator: contract.FilterOperatorNotIn, Value: []any{1.0, 2.0}},
+						{Field: "status", Operator: contract.FilterOperatorEqual, Value: "active"},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "a", Operator: contract.FilterOperatorEqual, Value: 1.0},
+									{Field: "b", Operator: contract.FilterOperatorEqual, Value: 2.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - unsupported operator",
+			input:   `{"$or": [{"a": 1}, {"tags": {"$elemMatch": {"$eq": "x"}}}]}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "$or.1.tags.$elemMatch", Operator: "$elemMatch", Error: "unsupported operator"}),
+		},
+		{
+			name:    "invalid input - unsupported top level operator",
+			input:   `{"$where": "this.a > 1"}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "$where", Operator: "$where", Error: "unsupported operator"}),
+		},
+		{
+			name:    "invalid input - unsupported regex",
+			input:   `{"name": {"$regex": "^a.*b"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "name.$regex", Operator: "$regex", Error: `unsupported pattern "^a.*b"`}),
+		},
+		{
+			name:    "invalid input - unsupported regex option",
+			input:   `{"name": {"$regex": "a", "$options": "m"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "name.$options", Operator: "$options", Error: "unsupported options m"}),
+		},
+		{
+			name:    "invalid input - case-insensitive regex",
+			input:   `{"name": {"$regex": "a", "$options": "i"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "name.$options", Operator: "$options", Error: "unsupported options i"}),
+		},
+		{
+			name:    "invalid input - embedded document",
+			input:   `{"address": {"city": "Prague"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "address", Operator: "$eq", Error: "comparing documents and arrays is not supported"}),
+		},
+		{
+			name:    "invalid input - negated prefix",
+			input:   `{"name": {"$not": {"$regex": "^a"}}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "name.$not", Operator: "$not", Error: `operator "begins" can't be negated`}),
+		},
+		{
+			name:    "invalid input - empty or",
+			input:   `{"$or": []}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "$or", Operator: "$or", Error: "expected a non-empty array of documents"}),
+		},
+		{
+			name:    "invalid input - in without array",
+			input:   `{"a": {"$in": "x"}}`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, MongoUnsupportedOperator{Path: "a.$in", Operator: "$in", Error: "expected an array of values"}),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := MongoInputTransformer{}
+			input, err := NewMongoInputFromJson([]byte(tt.input))
+			if err != nil {
+				t1.Fatalf("NewMongoInputFromJson() error = %v", err)
+			}
+			got, transformErr := t.Transform(input)
+			if !reflect.DeepEqual(transformErr, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", transformErr, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMongoInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   map[string]any
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   nil,
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   map[string]any{"age": map[string]any{"$gte": 18}},
+			want:    []byte(`{"age":{"$gte":18}}`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType(tt.input, &MongoInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
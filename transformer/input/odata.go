@@ -0,0 +1,415 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const (
+	odataTokenIdentifier tokenKind = iota + 1
+	odataTokenString
+	odataTokenLiteral
+	odataTokenOpenParenthesis
+	odataTokenCloseParenthesis
+	odataTokenComma
+)
+
+type odataOperandKind int
+
+const (
+	odataOperandField odataOperandKind = iota
+	odataOperandLiteral
+	odataOperandFunction
+)
+
+type odataOperand struct {
+	kind      odataOperandKind
+	name      string
+	value     any
+	arguments []odataOperand
+	column    int
+}
+
+var odataComparisonOperators = map[string]contract.FilterOperator{
+	"eq": contract.FilterOperatorEqual,
+	"ne": contract.FilterOperatorNotEqual,
+	"gt": contract.FilterOperatorGreaterThan,
+	"ge": contract.FilterOperatorGreaterThanOrEqual,
+	"lt": contract.FilterOperatorLowerThan,
+	"le": contract.FilterOperatorLowerThanOrEqual,
+}
+
+var odataFlippedOperators = map[contract.FilterOperator]contract.FilterOperator{
+	contract.FilterOperatorEqual:              contract.FilterOperatorEqual,
+	contract.FilterOperatorNotEqual:           contract.FilterOperatorNotEqual,
+	contract.FilterOperatorGreaterThan:        contract.FilterOperatorLowerThan,
+	contract.FilterOperatorGreaterThanOrEqual: contract.FilterOperatorLowerThanOrEqual,
+	contract.FilterOperatorLowerThan:          contract.FilterOperatorGreaterThan,
+	contract.FilterOperatorLowerThanOrEqual:   contract.FilterOperatorGreaterThanOrEqual,
+}
+
+var odataStringFunctions = map[string]contract.FilterOperator{
+	"startswith": contract.FilterOperatorBegins,
+	"endswith":   contract.FilterOperatorEnds,
+	"contains":   contract.FilterOperatorContains,
+	// OData v2 `substringof('value', Field)`
+	"substringof": contract.FilterOperatorContains,
+}
+
+// numbers (with optional type suffix), dates, times and GUIDs are lexed as a single literal
+var odataLiteralPattern = regexp.MustCompile(`^-?[0-9][0-9A-Za-z.:+\-]*`)
+var odataNumberSuffixPattern = regexp.MustCompile(`^(.*[0-9])[mMdDfFlL]$`)
+
+func lexOData(rawData string) ([]syntaxToken, *contract.Error) {
+	var tokens []syntaxToken
+	runes := []rune(rawData)
+	position := 0
+	for position < len(runes) {
+		character := runes[position]
+		start := position
+		switch {
+		case unicode.IsSpace(character):
+			position++
+		case character == '(':
+			tokens = append(tokens, syntaxToken{kind: odataTokenOpenParenthesis, value: "(", column: start + 1})
+			position++
+		case character == ')':
+			tokens = append(tokens, syntaxToken{kind: odataTokenCloseParenthesis, value: ")", column: start + 1})
+			position++
+		case character == ',':
+			tokens = append(tokens, syntaxToken{kind: odataTokenComma, value: ",", column: start + 1})
+			position++
+		case character == '\'':
+			value, end, closed := lexODataString(runes, position)
+			if !closed {
+				return nil, newSyntaxError("unterminated string", start+1)
+			}
+			position = end
+			tokens = append(tokens, syntaxToken{kind: odataTokenString, value: value, column: start + 1})
+		case unicode.IsDigit(character) || character == '-' && position+1 < len(runes) && unicode.IsDigit(runes[position+1]):
+			literal := odataLiteralPattern.FindString(string(runes[position:]))
+			position += len([]rune(literal))
+			tokens = append(tokens, syntaxToken{kind: odataTokenLiteral, value: literal, column: start + 1})
+		case unicode.IsLetter(character) || character == '_':
+			for position < len(runes) && (unicode.IsLetter(runes[position]) || unicode.IsDigit(runes[position]) || strings.ContainsRune("_/.", runes[position])) {
+				position++
+			}
+			identifier := string(runes[start:position])
+			// typed literals such as `datetime'2024-01-01'` or `guid'...'`
+			if position < len(runes) && runes[position] == '\'' {
+				value, end, closed := lexODataString(runes, position)
+				if !closed {
+					return nil, newSyntaxError("unterminated string", position+1)
+				}
+				position = end
+				tokens = append(tokens, syntaxToken{kind: odataTokenString, value: value, column: start + 1})
+				continue
+			}
+			tokens = append(tokens, syntaxToken{kind: odataTokenIdentifier, value: identifier, column: start + 1})
+		default:
+			return nil, newUnexpectedTokenError(string(character), start+1)
+		}
+	}
+	tokens = append(tokens, syntaxToken{kind: tokenEOF, column: len(runes) + 1})
+	return tokens, nil
+}
+
+// lexODataString reads a single-quoted string starting at the position (quotes are escaped by doubling them)
+func lexODataString(runes []rune, position int) (string, int, bool) {
+	var value strings.Builder
+	position++
+	for position < len(runes) {
+		if runes[position] == '\'' {
+			if position+1 < len(runes) && runes[position+1] == '\'' {
+				value.WriteRune('\'')
+				position += 2
+				continue
+			}
+			return value.String(), position + 1, true
+		}
+		value.WriteRune(runes[position])
+		position++
+	}
+	return "", position, false
+}
+
+func parseODataLiteral(literal string) any {
+	if match := odataNumberSuffixPattern.FindStringSubmatch(literal); match != nil && numberLiteralPattern.MatchString(match[1]) {
+		literal = match[1]
+	}
+	return parseLiteral(literal)
+}
+
+type odataParser struct {
+	tokenCursor
+}
+
+func (p *odataParser) isKeyword(keyword string) bool {
+	token := p.peek()
+	return token.kind == odataTokenIdentifier && token.value == keyword
+}
+
+// or := and ('or' and)*
+func (p *odataParser) parseOr() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if !p.isKeyword("or") {
+			return combineFilters(contract.FilterLogicOr, nodes), nil
+		}
+		p.next()
+	}
+}
+
+// and := unary ('and' unary)*
+func (p *odataParser) parseAnd() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseUnary()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if !p.isKeyword("and") {
+			return combineFilters(contract.FilterLogicAnd, nodes), nil
+		}
+		p.next()
+	}
+}
+
+// unary := 'not' unary | '(' or ')' | comparison
+func (p *odataParser) parseUnary() (contract.Filters, *contract.Error) {
+	if p.isKeyword("not") {
+		token := p.next()
+		filters, err := p.parseUnary()
+		if err != nil {
+			return filters, err
+		}
+		negated, operator, ok := negateFilters(filters)
+		if !ok {
+			return filters, newSyntaxError(fmt.Sprintf("operator %q can't be negated", operator), token.column)
+		}
+		return negated, nil
+	}
+	if p.peek().kind == odataTokenOpenParenthesis {
+		p.next()
+		filters, err := p.parseOr()
+		if err != nil {
+			return filters, err
+		}
+		_, err = p.expect(odataTokenCloseParenthesis)
+		return filters, err
+	}
+	return p.parseComparison()
+}
+
+// comparison := operand (('eq'|'ne'|'gt'|'ge'|'lt'|'le') operand | 'in' '(' operand (',' operand)* ')')?
+func (p *odataParser) parseComparison() (contract.Filters, *contract.Error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	token := p.peek()
+	if token.kind == odataTokenIdentifier && token.value == "in" {
+		p.next()
+		return p.parseIn(left)
+	}
+	operator, isComparison := odataComparisonOperators[token.value]
+	if token.kind != odataTokenIdentifier || !isComparison {
+		if left.kind == odataOperandFunction {
+			return p.newFunctionFilters(left, true)
+		}
+		return contract.Filters{}, token.unexpected()
+	}
+	p.next()
+	right, err := p.parseOperand()
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	if left.kind == odataOperandFunction || right.kind == odataOperandFunction {
+		function, literal := left, right
+		if right.kind == odataOperandFunction {
+			function, literal = right, left
+		}
+		if _, isSupported := odataStringFunctions[function.name]; !isSupported {
+			return contract.Filters{}, newSyntaxError(fmt.Sprintf("unsupported function %q", function.name), function.column)
+		}
+		expected, isBool := literal.value.(bool)
+		if literal.kind != odataOperandLiteral || !isBool || (operator != contract.FilterOperatorEqual && operator != contract.FilterOperatorNotEqual) {
+			return contract.Filters{}, newSyntaxError("functions can only be compared to true or false using eq or ne", token.column)
+		}
+		return p.newFunctionFilters(function, expected == (operator == contract.FilterOperatorEqual))
+	}
+	if left.kind == odataOperandLiteral && right.kind == odataOperandField {
+		left, right = right, left
+		operator = odataFlippedOperators[operator]
+	}
+	if left.kind != odataOperandField || right.kind != odataOperandLiteral {
+		return contract.Filters{}, newSyntaxError("expected comparison of a property and a literal", left.column)
+	}
+	condition := contract.FilterCondition{Field: left.name, Operator: operator, Value: right.value}
+	if right.value == nil {
+		switch operator {
+		case contract.FilterOperatorEqual:
+			condition.Operator = contract.FilterOperatorIsNil
+		case contract.FilterOperatorNotEqual:
+			condition.Operator = contract.FilterOperatorIsNotNil
+		default:
+			return contract.Filters{}, newSyntaxError("null can only be compared using eq or ne", right.column)
+		}
+	}
+	return newConditionFilters(condition), nil
+}
+
+func (p *odataParser) parseIn(left odataOperand) (contract.Filters, *contract.Error) {
+	if left.kind != odataOperandField {
+		return contract.Filters{}, newSyntaxError("expected property", left.column)
+	}
+	if _, err := p.expect(odataTokenOpenParenthesis); err != nil {
+		return contract.Filters{}, err
+	}
+	var values []any
+	for {
+		operand, err := p.parseOperand()
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		if operand.kind != odataOperandLiteral {
+			return contract.Filters{}, newSyntaxError("expected literal", operand.column)
+		}
+		values = append(values, operand.value)
+		separator := p.next()
+		if separator.kind == odataTokenCloseParenthesis {
+			break
+		}
+		if separator.kind != odataTokenComma {
+			return contract.Filters{}, separator.unexpected()
+		}
+	}
+	return newConditionFilters(contract.FilterCondition{
+		Field:    left.name,
+		Operator: contract.FilterOperatorIn,
+		Value:    values,
+	}), nil
+}
+
+// operand := property | literal | function '(' operand (',' operand)* ')'
+func (p *odataParser) parseOperand() (odataOperand, *contract.Error) {
+	token := p.next()
+	switch token.kind {
+	case odataTokenString:
+		return odataOperand{kind: odataOperandLiteral, value: token.value, column: token.column}, nil
+	case odataTokenLiteral:
+		return odataOperand{kind: odataOperandLiteral, value: parseODataLiteral(token.value), column: token.column}, nil
+	case odataTokenIdentifier:
+		switch token.value {
+		case "true", "false", "null":
+			return odataOperand{kind: odataOperandLiteral, value: parseLiteral(token.value), column: token.column}, nil
+		}
+		if p.peek().kind != odataTokenOpenParenthesis {
+			return odataOperand{kind: odataOperandField, name: strings.ReplaceAll(token.value, "/", "."), column: token.column}, nil
+		}
+		p.next()
+		function := odataOperand{kind: odataOperandFunction, name: token.value, column: token.column}
+		for {
+			argument, err := p.parseOperand()
+			if err != nil {
+				return function, err
+			}
+			function.arguments = append(function.arguments, argument)
+			separator := p.next()
+			if separator.kind == odataTokenCloseParenthesis {
+				return function, nil
+			}
+			if separator.kind != odataTokenComma {
+				return function, separator.unexpected()
+			}
+		}
+	}
+	return odataOperand{}, token.unexpected()
+}
+
+func (p *odataParser) newFunctionFilters(function odataOperand, expected bool) (contract.Filters, *contract.Error) {
+	operator, isSupported := odataStringFunctions[function.name]
+	if !isSupported {
+		return contract.Filters{}, newSyntaxError(fmt.Sprintf("unsupported function %q", function.name), function.column)
+	}
+	if len(function.arguments) != 2 {
+		return contract.Filters{}, newSyntaxError(fmt.Sprintf("function %q expects 2 arguments", function.name), function.column)
+	}
+	field, value := function.arguments[0], function.arguments[1]
+	if function.name == "substringof" {
+		field, value = value, field
+	}
+	if field.kind != odataOperandField || value.kind != odataOperandLiteral {
+		return contract.Filters{}, newSyntaxError(fmt.Sprintf("function %q expects a property and a literal", function.name), function.column)
+	}
+	filters := newConditionFilters(contract.FilterCondition{
+		Field:    field.name,
+		Operator: operator,
+		Value:    fmt.Sprint(value.value),
+	})
+	if expected {
+		return filters, nil
+	}
+	negated, _, ok := negateFilters(filters)
+	if !ok {
+		return filters, newSyntaxError(fmt.Sprintf("function %q can't be negated", function.name), function.column)
+	}
+	return negated, nil
+}
+
+type ODataInput struct {
+	contract.InputOutputType[string]
+}
+
+func (i *ODataInput) GetDataString() (string, error) {
+	return i.GetData()
+}
+
+func (i *ODataInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == "" {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+type ODataInputTransformer struct {
+}
+
+func (t *ODataInputTransformer) Transform(input *ODataInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if strings.TrimSpace(rawData) == "" {
+		return filters, nil
+	}
+	tokens, transformErr := lexOData(rawData)
+	if transformErr != nil {
+		return filters, transformErr
+	}
+	parser := odataParser{tokenCursor{tokens: tokens}}
+	filters, transformErr = parser.parseOr()
+	if transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	if transformErr = parser.expectEnd(); transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	return filters, nil
+}
@@ -0,0 +1,196 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestODataInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "comparison and string function",
+			input: "Price gt 20 and startswith(Name,'Mi')",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "Price", Operator: contract.FilterOperatorGreaterThan, Value: 20.0},
+						{Field: "Name", Operator: contract.FilterOperatorBegins, Value: "Mi"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "precedence of and over or",
+			input: "Status eq 'active' or Status eq 'trial' and Price le 9.5m",
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "Status", Operator: contract.FilterOperatorEqual, Value: "active"},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "Status", Operator: contract.FilterOperatorEqual, Value: "trial"},
+									{Field: "Price", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 9.5},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "functions, in, null and nested properties",
+			input: "(contains(Name,'O''Brien') or endswith(Name,'son') eq true) and Category/Name in ('a', 'b') and Parent eq null and Deleted ne null and 10 lt Stock and Created ge 2024-01-01",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "Category.Name", Operator: contract.FilterOperatorIn, Value: []any{"a", "b"}},
+						{Field: "Parent", Operator: contract.FilterOperatorIsNil, Value: nil},
+						{Field: "Deleted", Operator: contract.FilterOperatorIsNotNil, Value: nil},
+						{Field: "Stock", Operator: contract.FilterOperatorGreaterThan, Value: 10.0},
+						{Field: "Created", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: "2024-01-01"},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "Name", Operator: contract.FilterOperatorContains, Value: "O'Brien"},
+									{Field: "Name", Operator: contract.FilterOperatorEnds, Value: "son"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "negations",
+			input: "not contains(Name,'x') and substringof('y',Name) eq false and not (Price gt 5 or Status eq 'a')",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "Name", Operator: contract.FilterOperatorNotContains, Value: "x"},
+						{Field: "Name", Operator: contract.FilterOperatorNotContains, Value: "y"},
+						{Field: "Price", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 5.0},
+						{Field: "Status", Operator: contract.FilterOperatorNotEqual, Value: "a"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - unsupported function",
+			input:   "Price gt 20 and tolower(Name) eq 'x'",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unsupported function "tolower" at column 17`),
+		},
+		{
+			name:    "invalid input - function compared to a string",
+			input:   "contains(Name,'x') eq 'y'",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `functions can only be compared to true or false using eq or ne at column 20`),
+		},
+		{
+			name:    "invalid input - unknown function",
+			input:   "matchespattern(Name,'x')",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unsupported function "matchespattern" at column 1`),
+		},
+		{
+			name:    "invalid input - negated startswith",
+			input:   "not startswith(Name,'x')",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `operator "begins" can't be negated at column 1`),
+		},
+		{
+			name:    "invalid input - missing operand",
+			input:   "Price gt",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected end of input at column 9`),
+		},
+		{
+			name:    "invalid input - unterminated string",
+			input:   "Name eq 'x",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unterminated string at column 9`),
+		},
+		{
+			name:    "invalid input - comparison of properties",
+			input:   "Price gt Cost",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `expected comparison of a property and a literal at column 1`),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := ODataInputTransformer{}
+			input, _ := contract.NewInputOutputType(tt.input, &ODataInput{})
+			got, err := t.Transform(input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestODataInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   "Price gt 20",
+			want:    []byte(`"Price gt 20"`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType(tt.input, &ODataInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package input
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const (
+	QueryStringDefaultRootKey = "filter"
+	QueryStringDefaultMaxKeys = 100
+)
+
+type QueryStringInput struct {
+	contract.InputOutputType[url.Values]
+}
+
+func (i *QueryStringInput) GetDataString() (string, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return "", err
+	}
+	return rawData.Encode(), nil
+}
+
+func (i *QueryStringInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	return marshalBracketTree(rawData)
+}
+
+func NewQueryStringInputFromRawQuery(rawQuery string) (*QueryStringInput, error) {
+	data, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+	return contract.NewInputOutputType(data, &QueryStringInput{})
+}
+
+type QueryStringInputTransformer struct {
+	// RootKey defaults to QueryStringDefaultRootKey (e.g. `filter`, `f`, `where`)
+	RootKey string
+	// MaxKeys limits the number of parameters nested under the root key,
+	// defaults to QueryStringDefaultMaxKeys, negative value disables the limit
+	MaxKeys int
+}
+
+func (t *QueryStringInputTransformer) getRootKey() string {
+	if t.RootKey == "" {
+		return QueryStringDefaultRootKey
+	}
+	return t.RootKey
+}
+
+func (t *QueryStringInputTransformer) getMaxKeys() int {
+	if t.MaxKeys == 0 {
+		return QueryStringDefaultMaxKeys
+	}
+	return t.MaxKeys
+}
+
+func (t *QueryStringInputTransformer) Transform(input *QueryStringInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if rawData == nil {
+		return filters, nil
+	}
+	rootKey := t.getRootKey()
+	maxKeys := t.getMaxKeys()
+	if maxKeys > 0 {
+		count := 0
+		for key, values := range rawData {
+			if isBracketKeyUnder(key, rootKey) {
+				count += len(values)
+			}
+		}
+		if count > maxKeys {
+			return filters, contract.NewError(contract.InvalidInputDataStructure, fmt.Sprintf("too many keys: %d (max %d)", count, maxKeys))
+		}
+	}
+	return transformBracketTree(rawData, rootKey)
+}
@@ -0,0 +1,309 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testInputQueryString0, _ = NewQueryStringInputFromRawQuery("filter[logic]=or&filter[conditions][0][field]=key&filter[conditions][0][operator]=eq&filter[conditions][0][value]=val&filter[conditions][1][field]=key2&filter[conditions][1][operator]=in&filter[conditions][1][value][]=val&filter[conditions][1][value][]=val2&page=2")
+var testInputQueryString1, _ = NewQueryStringInputFromRawQuery("f[conditions][0][field]=key&f[conditions][0][operator]=not-null")
+var testInputQueryString2, _ = contract.NewInputOutputType(url.Values{
+	"where[conditions][0][field]":    {"key"},
+	"where[conditions][0][operator]": {"begins"},
+	"where[conditions][0][value]":    {"val"},
+}, &QueryStringInput{})
+var invalidInputQueryString0, _ = NewQueryStringInputFromRawQuery("filter[conditions]0[field]=key")
+
+func tooManyKeysQueryString(count int) *QueryStringInput {
+	parts := []string{"filter[conditions][0][field]=key", "filter[conditions][0][operator]=in"}
+	for index := len(parts); index < count; index++ {
+		parts = append(parts, "filter[conditions][0][value][]=val")
+	}
+	input, _ := NewQueryStringInputFromRawQuery(strings.Join(parts, "&"))
+	return input
+}
+
+func tooManyKeysFilters(count int) contract.Filters {
+	values := make([]any, count-2)
+	for index := range values {
+		values[index] = "val"
+	}
+	return contract.Filters{
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{
+				{
+					Field:    "key",
+					Operator: contract.FilterOperatorIn,
+					Value:    values,
+				},
+			},
+		},
+	}
+}
+
+func TestQueryStringInputTransformer_Transform(t1 *testing.T) {
+	type args struct {
+		input *QueryStringInput
+	}
+	tests := []struct {
+		name        string
+		transformer QueryStringInputTransformer
+		args        args
+		want        contract.Filters
+		wantErr     *contract.Error
+	}{
+		{
+			name: "empty input",
+			args: args{
+				input: &QueryStringInput{},
+			},
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name: "input with data",
+			args: args{
+				input: testInputQueryString0,
+			},
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{
+							Field:    "key",
+							Operator: contract.FilterOperatorEqual,
+							Value:    "val",
+						},
+						{
+							Field:    "key2",
+							Operator: contract.FilterOperatorIn,
+							Value:    []any{"val", "val2"},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "input with custom root key",
+			transformer: QueryStringInputTransformer{RootKey: "f"},
+			args: args{
+				input: testInputQueryString1,
+			},
+			want: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{
+							Field:    "key",
+							Operator: contract.FilterOperatorIsNotNil,
+							Value:    nil,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "input from url values",
+			transformer: QueryStringInputTransformer{RootKey: "where"},
+			args: args{
+				input: testInputQueryString2,
+			},
+			want: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{
+							Field:    "key",
+							Operator: contract.FilterOperatorBegins,
+							Value:    "val",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "input with different root key",
+			args: args{
+				input: testInputQueryString1,
+			},
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name: "input within the default key limit",
+			args: args{
+				input: tooManyKeysQueryString(QueryStringDefaultMaxKeys),
+			},
+			want:    tooManyKeysFilters(QueryStringDefaultMaxKeys),
+			wantErr: nil,
+		},
+		{
+			name: "invalid input - too many keys",
+			args: args{
+				input: tooManyKeysQueryString(QueryStringDefaultMaxKeys + 1),
+			},
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "too many keys: 101 (max 100)"),
+		},
+		{
+			name:        "invalid input - too many keys with custom limit",
+			transformer: QueryStringInputTransformer{MaxKeys: 5},
+			args: args{
+				input: testInputQueryString0,
+			},
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "too many keys: 8 (max 5)"),
+		},
+		{
+			name:        "input without key limit",
+			transformer: QueryStringInputTransformer{MaxKeys: -1},
+			args: args{
+				input: tooManyKeysQueryString(QueryStringDefaultMaxKeys + 1),
+			},
+			want:    tooManyKeysFilters(QueryStringDefaultMaxKeys + 1),
+			wantErr: nil,
+		},
+		{
+			name: "invalid input - malformed key",
+			args: args{
+				input: invalidInputQueryString0,
+			},
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, "filter[conditions]0[field]: unexpected bracket"),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			got, err := tt.transformer.Transform(tt.args.input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewQueryStringInputFromRawQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		want     url.Values
+		wantErr  bool
+	}{
+		{
+			name:     "empty query",
+			rawQuery: "",
+			want:     url.Values{},
+			wantErr:  false,
+		},
+		{
+			name:     "encoded query",
+			rawQuery: "filter%5Blogic%5D=and&filter[conditions][0][value]=a%20b",
+			want:     url.Values{"filter[logic]": {"and"}, "filter[conditions][0][value]": {"a b"}},
+			wantErr:  false,
+		},
+		{
+			name:     "invalid escape",
+			rawQuery: "filter[logic]=%zz",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewQueryStringInputFromRawQuery(tt.rawQuery)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewQueryStringInputFromRawQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			data, _ := got.GetData()
+			if !reflect.DeepEqual(data, tt.want) {
+				t.Errorf("NewQueryStringInputFromRawQuery() got = %v, want %v", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryStringInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name             string
+		queryStringInput QueryStringInput
+		want             []byte
+		wantErr          bool
+	}{
+		{
+			name:             "empty input",
+			queryStringInput: QueryStringInput{},
+			want:             nil,
+			wantErr:          false,
+		},
+		{
+			name:             "input with data",
+			queryStringInput: *testInputQueryString1,
+			want:             []byte(`{"f":{"conditions":[{"field":"key","operator":"not-null"}]}}`),
+			wantErr:          false,
+		},
+		{
+			name:             "invalid input",
+			queryStringInput: *invalidInputQueryString0,
+			want:             nil,
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.queryStringInput.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryStringInput_GetDataString(t *testing.T) {
+	tests := []struct {
+		name             string
+		queryStringInput QueryStringInput
+		want             string
+		wantErr          bool
+	}{
+		{
+			name:             "empty input",
+			queryStringInput: QueryStringInput{},
+			want:             "",
+			wantErr:          false,
+		},
+		{
+			name:             "input with data",
+			queryStringInput: *testInputQueryString1,
+			want:             "f%5Bconditions%5D%5B0%5D%5Bfield%5D=key&f%5Bconditions%5D%5B0%5D%5Boperator%5D=not-null",
+			wantErr:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.queryStringInput.GetDataString()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetDataString() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
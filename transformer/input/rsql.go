@@ -0,0 +1,325 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const (
+	rsqlTokenString tokenKind = iota + 1
+	rsqlTokenComparison
+	rsqlTokenAnd
+	rsqlTokenOr
+	rsqlTokenOpenParenthesis
+	rsqlTokenCloseParenthesis
+)
+
+const rsqlReservedCharacters = "\"'();,=!~<>"
+
+var rsqlComparisonOperators = map[string]contract.FilterOperator{
+	"==":    contract.FilterOperatorEqual,
+	"!=":    contract.FilterOperatorNotEqual,
+	"=gt=":  contract.FilterOperatorGreaterThan,
+	">":     contract.FilterOperatorGreaterThan,
+	"=ge=":  contract.FilterOperatorGreaterThanOrEqual,
+	">=":    contract.FilterOperatorGreaterThanOrEqual,
+	"=lt=":  contract.FilterOperatorLowerThan,
+	"<":     contract.FilterOperatorLowerThan,
+	"=le=":  contract.FilterOperatorLowerThanOrEqual,
+	"<=":    contract.FilterOperatorLowerThanOrEqual,
+	"=in=":  contract.FilterOperatorIn,
+	"=out=": contract.FilterOperatorNotIn,
+	// `=isnull=true` and `=isnull=false`
+	"=isnull=": contract.FilterOperatorIsNil,
+}
+
+func lexRSQL(rawData string) ([]syntaxToken, *contract.Error) {
+	var tokens []syntaxToken
+	runes := []rune(rawData)
+	position := 0
+	for position < len(runes) {
+		character := runes[position]
+		start := position
+		switch {
+		case unicode.IsSpace(character):
+			position++
+		case character == '(':
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenOpenParenthesis, value: "(", column: start + 1})
+			position++
+		case character == ')':
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenCloseParenthesis, value: ")", column: start + 1})
+			position++
+		case character == ';':
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenAnd, value: ";", column: start + 1})
+			position++
+		case character == ',':
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenOr, value: ",", column: start + 1})
+			position++
+		case character == '=':
+			position++
+			for position < len(runes) && unicode.IsLetter(runes[position]) {
+				position++
+			}
+			if position >= len(runes) || runes[position] != '=' {
+				return nil, newSyntaxError("invalid comparison operator", start+1)
+			}
+			position++
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenComparison, value: string(runes[start:position]), column: start + 1})
+		case character == '!' || character == '<' || character == '>':
+			position++
+			if position < len(runes) && runes[position] == '=' {
+				position++
+			} else if character == '!' {
+				return nil, newSyntaxError("invalid comparison operator", start+1)
+			}
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenComparison, value: string(runes[start:position]), column: start + 1})
+		case character == '"' || character == '\'':
+			position++
+			closed := false
+			for position < len(runes) {
+				if runes[position] == '\\' && position+1 < len(runes) {
+					position += 2
+					continue
+				}
+				position++
+				if runes[position-1] == character {
+					closed = true
+					break
+				}
+			}
+			if !closed {
+				return nil, newSyntaxError("unterminated string", start+1)
+			}
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenString, value: string(runes[start+1 : position-1]), verbatim: true, column: start + 1})
+		default:
+			for position < len(runes) && !unicode.IsSpace(runes[position]) && !strings.ContainsRune(rsqlReservedCharacters, runes[position]) {
+				if runes[position] == '\\' && position+1 < len(runes) {
+					position++
+				}
+				position++
+			}
+			if position == start {
+				return nil, newUnexpectedTokenError(string(character), start+1)
+			}
+			tokens = append(tokens, syntaxToken{kind: rsqlTokenString, value: string(runes[start:position]), column: start + 1})
+		}
+	}
+	tokens = append(tokens, syntaxToken{kind: tokenEOF, column: len(runes) + 1})
+	return tokens, nil
+}
+
+type rsqlParser struct {
+	tokenCursor
+}
+
+// or := and (',' and)*
+func (p *rsqlParser) parseOr() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if p.peek().kind != rsqlTokenOr {
+			return combineFilters(contract.FilterLogicOr, nodes), nil
+		}
+		p.next()
+	}
+}
+
+// and := constraint (';' constraint)*
+func (p *rsqlParser) parseAnd() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseConstraint()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if p.peek().kind != rsqlTokenAnd {
+			return combineFilters(contract.FilterLogicAnd, nodes), nil
+		}
+		p.next()
+	}
+}
+
+// constraint := '(' or ')' | selector operator argument
+func (p *rsqlParser) parseConstraint() (contract.Filters, *contract.Error) {
+	token := p.next()
+	if token.kind == rsqlTokenOpenParenthesis {
+		filters, err := p.parseOr()
+		if err != nil {
+			return filters, err
+		}
+		_, err = p.expect(rsqlTokenCloseParenthesis)
+		return filters, err
+	}
+	if token.kind != rsqlTokenString || token.verbatim {
+		return contract.Filters{}, token.unexpected()
+	}
+	selector := unescapeBackslashes(token.value)
+	comparison, err := p.expect(rsqlTokenComparison)
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	operator, isSupported := rsqlComparisonOperators[comparison.value]
+	if !isSupported {
+		return contract.Filters{}, newSyntaxError(fmt.Sprintf("unsupported comparison operator %q", comparison.value), comparison.column)
+	}
+	arguments, err := p.parseArguments()
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	condition, err := newRSQLCondition(selector, operator, arguments)
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	return newConditionFilters(condition), nil
+}
+
+// arguments := '(' value (',' value)* ')' | value
+func (p *rsqlParser) parseArguments() ([]syntaxToken, *contract.Error) {
+	token := p.next()
+	if token.kind == rsqlTokenString {
+		return []syntaxToken{token}, nil
+	}
+	if token.kind != rsqlTokenOpenParenthesis {
+		return nil, token.unexpected()
+	}
+	var arguments []syntaxToken
+	for {
+		argument, err := p.expect(rsqlTokenString)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+		separator := p.next()
+		if separator.kind == rsqlTokenCloseParenthesis {
+			return arguments, nil
+		}
+		if separator.kind != rsqlTokenOr {
+			return nil, separator.unexpected()
+		}
+	}
+}
+
+func rsqlArgumentValue(argument syntaxToken) any {
+	value := unescapeBackslashes(argument.value)
+	if argument.verbatim {
+		return value
+	}
+	return parseLiteral(value)
+}
+
+func newRSQLCondition(selector string, operator contract.FilterOperator, arguments []syntaxToken) (contract.FilterCondition, *contract.Error) {
+	condition := contract.FilterCondition{Field: selector, Operator: operator}
+	if operator == contract.FilterOperatorIn || operator == contract.FilterOperatorNotIn {
+		values := make([]any, len(arguments))
+		for index, argument := range arguments {
+			values[index] = rsqlArgumentValue(argument)
+		}
+		condition.Value = values
+		return condition, nil
+	}
+	if len(arguments) > 1 {
+		return condition, newSyntaxError("unexpected list of arguments", arguments[0].column)
+	}
+	argument := arguments[0]
+	if operator == contract.FilterOperatorIsNil {
+		switch rsqlArgumentValue(argument) {
+		case true:
+			return condition, nil
+		case false:
+			condition.Operator = contract.FilterOperatorIsNotNil
+			return condition, nil
+		}
+		return condition, newSyntaxError("expected true or false", argument.column)
+	}
+	if operator != contract.FilterOperatorEqual && operator != contract.FilterOperatorNotEqual {
+		condition.Value = rsqlArgumentValue(argument)
+		return condition, nil
+	}
+
+	value, leading, trailing, problem := parseWildcardPattern(argument.value, '*')
+	if problem != "" {
+		return condition, newSyntaxError(problem, argument.column)
+	}
+	switch {
+	case leading && trailing:
+		condition.Operator = contract.FilterOperatorContains
+		if operator == contract.FilterOperatorNotEqual {
+			condition.Operator = contract.FilterOperatorNotContains
+		}
+		condition.Value = value
+	case leading || trailing:
+		if operator == contract.FilterOperatorNotEqual {
+			return condition, newSyntaxError("unsupported negated wildcard", argument.column)
+		}
+		condition.Operator = contract.FilterOperatorBegins
+		if leading {
+			condition.Operator = contract.FilterOperatorEnds
+		}
+		condition.Value = value
+	default:
+		condition.Value = rsqlArgumentValue(argument)
+		if condition.Value == nil && !argument.verbatim {
+			// `field==null` and `field!=null`
+			condition.Operator = contract.FilterOperatorIsNil
+			if operator == contract.FilterOperatorNotEqual {
+				condition.Operator = contract.FilterOperatorIsNotNil
+			}
+		}
+	}
+	return condition, nil
+}
+
+type RSQLInput struct {
+	contract.InputOutputType[string]
+}
+
+func (i *RSQLInput) GetDataString() (string, error) {
+	return i.GetData()
+}
+
+func (i *RSQLInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == "" {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+type RSQLInputTransformer struct {
+}
+
+func (t *RSQLInputTransformer) Transform(input *RSQLInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if strings.TrimSpace(rawData) == "" {
+		return filters, nil
+	}
+	tokens, transformErr := lexRSQL(rawData)
+	if transformErr != nil {
+		return filters, transformErr
+	}
+	parser := rsqlParser{tokenCursor{tokens: tokens}}
+	filters, transformErr = parser.parseOr()
+	if transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	if transformErr = parser.expectEnd(); transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	return filters, nil
+}
@@ -0,0 +1,237 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestRSQLInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "single comparison",
+			input: "name==foo",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "name", Operator: contract.FilterOperatorEqual, Value: "foo"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "and with or",
+			input: "name==foo;age=gt=30,status=in=(a,b)",
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "status", Operator: contract.FilterOperatorIn, Value: []any{"a", "b"}},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "name", Operator: contract.FilterOperatorEqual, Value: "foo"},
+									{Field: "age", Operator: contract.FilterOperatorGreaterThan, Value: 30.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "all comparison operators",
+			input: "a!=1;b>=2;c<3;d=le=4;e>5;f=lt=6;g=ge=7;h<=8;i=out=(x,'9');j=in=y;k=isnull=true;l=isnull=false",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorNotEqual, Value: 1.0},
+						{Field: "b", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 2.0},
+						{Field: "c", Operator: contract.FilterOperatorLowerThan, Value: 3.0},
+						{Field: "d", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 4.0},
+						{Field: "e", Operator: contract.FilterOperatorGreaterThan, Value: 5.0},
+						{Field: "f", Operator: contract.FilterOperatorLowerThan, Value: 6.0},
+						{Field: "g", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 7.0},
+						{Field: "h", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 8.0},
+						{Field: "i", Operator: contract.FilterOperatorNotIn, Value: []any{"x", "9"}},
+						{Field: "j", Operator: contract.FilterOperatorIn, Value: []any{"y"}},
+						{Field: "k", Operator: contract.FilterOperatorIsNil},
+						{Field: "l", Operator: contract.FilterOperatorIsNotNil},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "wildcards",
+			input: `a==foo*;b==*foo;c=="*foo bar*";d!=*foo*;e==\*foo;f==null;g!=null;h=='null'`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorBegins, Value: "foo"},
+						{Field: "b", Operator: contract.FilterOperatorEnds, Value: "foo"},
+						{Field: "c", Operator: contract.FilterOperatorContains, Value: "foo bar"},
+						{Field: "d", Operator: contract.FilterOperatorNotContains, Value: "foo"},
+						{Field: "e", Operator: contract.FilterOperatorEqual, Value: "*foo"},
+						{Field: "f", Operator: contract.FilterOperatorIsNil},
+						{Field: "g", Operator: contract.FilterOperatorIsNotNil},
+						{Field: "h", Operator: contract.FilterOperatorEqual, Value: "null"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "nested groups",
+			input: "(a==1,b==2);(c==3,d==4)",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "a", Operator: contract.FilterOperatorEqual, Value: 1.0},
+									{Field: "b", Operator: contract.FilterOperatorEqual, Value: 2.0},
+								},
+							},
+						},
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "c", Operator: contract.FilterOperatorEqual, Value: 3.0},
+									{Field: "d", Operator: contract.FilterOperatorEqual, Value: 4.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - unsupported operator",
+			input:   "name=like=foo",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unsupported comparison operator "=like=" at column 5`),
+		},
+		{
+			name:    "invalid input - inner wildcard",
+			input:   "name==f*o",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unsupported wildcard position at column 7`),
+		},
+		{
+			name:    "invalid input - only a wildcard",
+			input:   "name==*",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `wildcard without a value at column 7`),
+		},
+		{
+			name:    "invalid input - only wildcards",
+			input:   "name!=**",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `wildcard without a value at column 7`),
+		},
+		{
+			name:    "invalid input - negated prefix",
+			input:   "name!=foo*",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unsupported negated wildcard at column 7`),
+		},
+		{
+			name:    "invalid input - missing argument",
+			input:   "name==;age>1",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token ";" at column 7`),
+		},
+		{
+			name:    "invalid input - unclosed group",
+			input:   "(name==foo",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected end of input at column 11`),
+		},
+		{
+			name:    "invalid input - list for scalar operator",
+			input:   "name==(a,b)",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected list of arguments at column 8`),
+		},
+		{
+			name:    "invalid input - trailing token",
+			input:   "name==foo)",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token ")" at column 10`),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := RSQLInputTransformer{}
+			input, _ := contract.NewInputOutputType(tt.input, &RSQLInput{})
+			got, err := t.Transform(input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRSQLInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   `name=="foo"`,
+			want:    []byte(`"name==\"foo\""`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType(tt.input, &RSQLInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
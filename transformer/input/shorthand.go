@@ -0,0 +1,257 @@
+package input
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const (
+	shorthandTokenText tokenKind = iota + 1
+	shorthandTokenQuoted
+	shorthandTokenColon
+	shorthandTokenComma
+	shorthandTokenSemicolon
+	shorthandTokenOpenParenthesis
+	shorthandTokenCloseParenthesis
+	shorthandTokenPipe
+)
+
+var shorthandSpecialCharacters = map[rune]tokenKind{
+	':': shorthandTokenColon,
+	',': shorthandTokenComma,
+	';': shorthandTokenSemicolon,
+	'(': shorthandTokenOpenParenthesis,
+	')': shorthandTokenCloseParenthesis,
+	'|': shorthandTokenPipe,
+}
+
+func lexShorthand(rawData string) ([]syntaxToken, *contract.Error) {
+	var tokens []syntaxToken
+	runes := []rune(rawData)
+	position := 0
+	for position < len(runes) {
+		character := runes[position]
+		if unicode.IsSpace(character) {
+			position++
+			continue
+		}
+		if kind, isSpecial := shorthandSpecialCharacters[character]; isSpecial {
+			tokens = append(tokens, syntaxToken{kind: kind, value: string(character), column: position + 1})
+			position++
+			continue
+		}
+		start := position
+		var value strings.Builder
+		if character == '"' {
+			position++
+			closed := false
+			for position < len(runes) {
+				character = runes[position]
+				if character == '\\' && position+1 < len(runes) {
+					value.WriteRune(runes[position+1])
+					position += 2
+					continue
+				}
+				position++
+				if character == '"' {
+					closed = true
+					break
+				}
+				value.WriteRune(character)
+			}
+			if !closed {
+				return nil, newSyntaxError("unterminated string", start+1)
+			}
+			tokens = append(tokens, syntaxToken{kind: shorthandTokenQuoted, value: value.String(), column: start + 1})
+			continue
+		}
+		escaped := false
+		trimmedLength := 0
+		for position < len(runes) {
+			character = runes[position]
+			if _, isSpecial := shorthandSpecialCharacters[character]; isSpecial {
+				break
+			}
+			if character == '\\' {
+				if position+1 >= len(runes) {
+					return nil, newSyntaxError("unexpected end of input after escape character", position+1)
+				}
+				value.WriteRune(runes[position+1])
+				trimmedLength = value.Len()
+				escaped = true
+				position += 2
+				continue
+			}
+			value.WriteRune(character)
+			if !unicode.IsSpace(character) {
+				trimmedLength = value.Len()
+			}
+			position++
+		}
+		tokens = append(tokens, syntaxToken{
+			kind:     shorthandTokenText,
+			value:    value.String()[:trimmedLength],
+			column:   start + 1,
+			verbatim: escaped,
+		})
+	}
+	tokens = append(tokens, syntaxToken{kind: tokenEOF, column: len(runes) + 1})
+	return tokens, nil
+}
+
+type shorthandParser struct {
+	tokenCursor
+}
+
+// expression := group (';' group)*
+func (p *shorthandParser) parseExpression() (contract.Filters, *contract.Error) {
+	var groups []contract.Filters
+	for {
+		group, err := p.parseGroup()
+		if err != nil {
+			return group, err
+		}
+		groups = append(groups, group)
+		if p.peek().kind != shorthandTokenSemicolon {
+			return combineFilters(contract.FilterLogicOr, groups), nil
+		}
+		p.next()
+	}
+}
+
+// group := term (',' term)*
+func (p *shorthandParser) parseGroup() (contract.Filters, *contract.Error) {
+	var terms []contract.Filters
+	for {
+		term, err := p.parseTerm()
+		if err != nil {
+			return term, err
+		}
+		terms = append(terms, term)
+		if p.peek().kind != shorthandTokenComma {
+			return combineFilters(contract.FilterLogicAnd, terms), nil
+		}
+		p.next()
+	}
+}
+
+// term := '(' expression ')' | field ':' operator [':' value ('|' value)*]
+func (p *shorthandParser) parseTerm() (contract.Filters, *contract.Error) {
+	if p.peek().kind == shorthandTokenOpenParenthesis {
+		p.next()
+		filters, err := p.parseExpression()
+		if err != nil {
+			return filters, err
+		}
+		_, err = p.expectDescribed(shorthandTokenCloseParenthesis, "expected \")\"")
+		return filters, err
+	}
+	field, err := p.expectDescribed(shorthandTokenText, "expected field")
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	if field.value == "" {
+		return contract.Filters{}, newSyntaxError("expected field", field.column)
+	}
+	if _, err = p.expectDescribed(shorthandTokenColon, "expected \":\""); err != nil {
+		return contract.Filters{}, err
+	}
+	operator, err := p.expectDescribed(shorthandTokenText, "expected operator")
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	condition := contract.FilterCondition{
+		Field:    field.value,
+		Operator: contract.FilterOperator(operator.value),
+	}
+	if p.peek().kind == shorthandTokenColon {
+		p.next()
+		condition.Value, err = p.parseValue(condition.Operator)
+		if err != nil {
+			return contract.Filters{}, err
+		}
+	}
+	return newConditionFilters(condition), nil
+}
+
+func (p *shorthandParser) parseValue(operator contract.FilterOperator) (any, *contract.Error) {
+	values := []any{p.parseScalar()}
+	for p.peek().kind == shorthandTokenPipe {
+		pipe := p.next()
+		if !slices.Contains([]contract.FilterOperator{contract.FilterOperatorIn, contract.FilterOperatorNotIn}, operator) {
+			return nil, pipe.unexpected()
+		}
+		values = append(values, p.parseScalar())
+	}
+	if operator == contract.FilterOperatorIn || operator == contract.FilterOperatorNotIn {
+		return values, nil
+	}
+	return values[0], nil
+}
+
+func (p *shorthandParser) parseScalar() any {
+	token := p.peek()
+	switch token.kind {
+	case shorthandTokenQuoted:
+		p.next()
+		return token.value
+	case shorthandTokenText:
+		p.next()
+		if token.verbatim {
+			return token.value
+		}
+		return parseLiteral(token.value)
+	}
+	// missing value (e.g. `name:eq:`) is an empty string
+	return ""
+}
+
+type ShorthandInput struct {
+	contract.InputOutputType[string]
+}
+
+func (i *ShorthandInput) GetDataString() (string, error) {
+	return i.GetData()
+}
+
+func (i *ShorthandInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == "" {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+type ShorthandInputTransformer struct {
+}
+
+func (t *ShorthandInputTransformer) Transform(input *ShorthandInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if strings.TrimSpace(rawData) == "" {
+		return filters, nil
+	}
+	tokens, transformErr := lexShorthand(rawData)
+	if transformErr != nil {
+		return filters, transformErr
+	}
+	parser := shorthandParser{tokenCursor{tokens: tokens}}
+	filters, transformErr = parser.parseExpression()
+	if transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	if transformErr = parser.expectEnd(); transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	return filters, nil
+}
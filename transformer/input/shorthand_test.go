@@ -0,0 +1,207 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestShorthandInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "single condition",
+			input: "status:eq:active",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "status", Operator: contract.FilterOperatorEqual, Value: "active"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "and conditions with literals",
+			input: "status:eq:active, price:gt:10, deleted:eq:false, parent:nil",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "status", Operator: contract.FilterOperatorEqual, Value: "active"},
+						{Field: "price", Operator: contract.FilterOperatorGreaterThan, Value: 10.0},
+						{Field: "deleted", Operator: contract.FilterOperatorEqual, Value: false},
+						{Field: "parent", Operator: contract.FilterOperatorIsNil, Value: nil},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "or groups",
+			input: "status:eq:active,price:gt:10;status:eq:trial",
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "status", Operator: contract.FilterOperatorEqual, Value: "trial"},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "status", Operator: contract.FilterOperatorEqual, Value: "active"},
+									{Field: "price", Operator: contract.FilterOperatorGreaterThan, Value: 10.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "nested groups and lists",
+			input: "(status:eq:active;status:eq:trial),tag:in:a|b|\"10\"",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "tag", Operator: contract.FilterOperatorIn, Value: []any{"a", "b", "10"}},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "status", Operator: contract.FilterOperatorEqual, Value: "active"},
+									{Field: "status", Operator: contract.FilterOperatorEqual, Value: "trial"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "escaped and quoted values",
+			input: `name:contains:a\,b\:c, title:eq:"x, (y); z", code:eq:\10`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "name", Operator: contract.FilterOperatorContains, Value: "a,b:c"},
+						{Field: "title", Operator: contract.FilterOperatorEqual, Value: "x, (y); z"},
+						{Field: "code", Operator: contract.FilterOperatorEqual, Value: "10"},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "empty value",
+			input: "name:eq:",
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "name", Operator: contract.FilterOperatorEqual, Value: ""},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - unexpected token",
+			input:   "status:eq:active) ,price:gt:10",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token ")" at column 17`),
+		},
+		{
+			name:    "invalid input - missing operator",
+			input:   "status:eq:active,price",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `expected ":", got end of input at column 23`),
+		},
+		{
+			name:    "invalid input - unclosed parenthesis",
+			input:   "(status:eq:active",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `expected ")", got end of input at column 18`),
+		},
+		{
+			name:    "invalid input - list for scalar operator",
+			input:   "status:eq:a|b",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unexpected token "|" at column 12`),
+		},
+		{
+			name:    "invalid input - unterminated string",
+			input:   `status:eq:"active`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, `unterminated string at column 11`),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := ShorthandInputTransformer{}
+			input, _ := contract.NewInputOutputType(tt.input, &ShorthandInput{})
+			got, err := t.Transform(input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShorthandInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   `status:eq:"active"`,
+			want:    []byte(`"status:eq:\"active\""`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType(tt.input, &ShorthandInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
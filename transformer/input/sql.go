@@ -0,0 +1,573 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const (
+	sqlTokenWord tokenKind = iota + 1
+	sqlTokenQuotedIdentifier
+	sqlTokenString
+	sqlTokenNumber
+	sqlTokenOperator
+	sqlTokenPlaceholder
+	sqlTokenOpenParenthesis
+	sqlTokenCloseParenthesis
+	sqlTokenComma
+	// any other character, it is reported by the parser so that e.g. a whole subquery can be pointed at
+	sqlTokenSymbol
+)
+
+// longer operators need to go first so that they are matched greedily
+var sqlOperatorSymbols = []string{"<>", "!=", "<=", ">=", "=", "<", ">"}
+
+var sqlOperators = map[string]contract.FilterOperator{
+	"=":  contract.FilterOperatorEqual,
+	"<>": contract.FilterOperatorNotEqual,
+	"!=": contract.FilterOperatorNotEqual,
+	">":  contract.FilterOperatorGreaterThan,
+	">=": contract.FilterOperatorGreaterThanOrEqual,
+	"<":  contract.FilterOperatorLowerThan,
+	"<=": contract.FilterOperatorLowerThanOrEqual,
+}
+
+var sqlKeywords = []string{"AND", "OR", "NOT", "IN", "IS", "NULL", "LIKE", "ILIKE", "BETWEEN", "TRUE", "FALSE", "SELECT", "EXISTS", "ESCAPE", "WHERE"}
+
+var sqlNumberPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?`)
+
+// SQLFragmentError is the payload of the error returned for a part of the predicate that can't be converted to filters
+type SQLFragmentError struct {
+	Fragment string
+	Column   int
+	Error    string
+}
+
+func newSQLFragmentError(fragment string, column int, message string) *contract.Error {
+	return contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{
+		Fragment: fragment,
+		Column:   column,
+		Error:    message,
+	})
+}
+
+func isSQLIdentifierCharacter(character rune) bool {
+	return unicode.IsLetter(character) || unicode.IsDigit(character) || character == '_' || character == '$'
+}
+
+var sqlIdentifierQuotes = map[rune]rune{'"': '"', '`': '`', '[': ']'}
+
+// lexSQLQuoted reads the quoted part starting at the position, a quote inside is escaped by doubling it
+func lexSQLQuoted(runes []rune, position int, closingQuote rune) (string, int, bool) {
+	var value strings.Builder
+	position++
+	for position < len(runes) {
+		if runes[position] == closingQuote {
+			if position+1 < len(runes) && runes[position+1] == closingQuote {
+				value.WriteRune(closingQuote)
+				position += 2
+				continue
+			}
+			return value.String(), position + 1, true
+		}
+		value.WriteRune(runes[position])
+		position++
+	}
+	return "", position, false
+}
+
+// lexSQLIdentifier reads a possibly qualified identifier such as `t.column`, `"t"."column"` or `[t].[column]`
+func lexSQLIdentifier(runes []rune, position int) (token syntaxToken, err *contract.Error) {
+	start := position
+	token = syntaxToken{kind: sqlTokenWord, column: start + 1}
+	var parts []string
+	for {
+		if closingQuote, isQuoted := sqlIdentifierQuotes[runes[position]]; isQuoted {
+			part, end, ok := lexSQLQuoted(runes, position, closingQuote)
+			if !ok {
+				return token, newSQLFragmentError(string(runes[position:]), position+1, "unterminated identifier")
+			}
+			parts = append(parts, part)
+			token.kind = sqlTokenQuotedIdentifier
+			position = end
+		} else {
+			partStart := position
+			for position < len(runes) && isSQLIdentifierCharacter(runes[position]) {
+				position++
+			}
+			if position == partStart {
+				return token, newSQLFragmentError(string(runes[start:position]), start+1, "invalid identifier")
+			}
+			parts = append(parts, string(runes[partStart:position]))
+		}
+		if position+1 >= len(runes) || runes[position] != '.' {
+			break
+		}
+		position++
+	}
+	token.value = strings.Join(parts, ".")
+	token.end = position
+	return token, nil
+}
+
+func lexSQL(runes []rune) ([]syntaxToken, *contract.Error) {
+	var tokens []syntaxToken
+	position := 0
+	punctuation := map[rune]tokenKind{
+		'(': sqlTokenOpenParenthesis,
+		')': sqlTokenCloseParenthesis,
+		',': sqlTokenComma,
+	}
+lexing:
+	for position < len(runes) {
+		character := runes[position]
+		start := position
+		if unicode.IsSpace(character) {
+			position++
+			continue
+		}
+		if kind, isPunctuation := punctuation[character]; isPunctuation {
+			position++
+			tokens = append(tokens, syntaxToken{kind: kind, value: string(character), column: start + 1, end: position})
+			continue
+		}
+		if character == '\'' {
+			value, end, ok := lexSQLQuoted(runes, position, '\'')
+			if !ok {
+				return nil, newSQLFragmentError(string(runes[start:]), start+1, "unterminated string")
+			}
+			position = end
+			tokens = append(tokens, syntaxToken{kind: sqlTokenString, value: value, column: start + 1, end: position})
+			continue
+		}
+		for _, symbol := range sqlOperatorSymbols {
+			if strings.HasPrefix(string(runes[position:]), symbol) {
+				position += len(symbol)
+				tokens = append(tokens, syntaxToken{kind: sqlTokenOperator, value: symbol, column: start + 1, end: position})
+				continue lexing
+			}
+		}
+		if number := sqlNumberPattern.FindString(string(runes[position:])); number != "" {
+			position += len(number)
+			tokens = append(tokens, syntaxToken{kind: sqlTokenNumber, value: number, column: start + 1, end: position})
+			continue
+		}
+		if character == '?' || character == '$' || character == ':' {
+			// `?`, `$1` and `:name`
+			position++
+			for position < len(runes) && isSQLIdentifierCharacter(runes[position]) {
+				position++
+			}
+			tokens = append(tokens, syntaxToken{kind: sqlTokenPlaceholder, value: string(runes[start:position]), column: start + 1, end: position})
+			continue
+		}
+		if _, isQuoted := sqlIdentifierQuotes[character]; isQuoted || isSQLIdentifierCharacter(character) {
+			token, err := lexSQLIdentifier(runes, position)
+			if err != nil {
+				return nil, err
+			}
+			position = token.end
+			tokens = append(tokens, token)
+			continue
+		}
+		position++
+		tokens = append(tokens, syntaxToken{kind: sqlTokenSymbol, value: string(character), column: start + 1, end: position})
+	}
+	tokens = append(tokens, syntaxToken{kind: tokenEOF, column: len(runes) + 1, end: len(runes)})
+	return tokens, nil
+}
+
+type sqlParser struct {
+	runes []rune
+	tokenCursor
+}
+
+func (p *sqlParser) isKeyword(keyword string) bool {
+	return p.peek().isKeyword(sqlTokenWord, keyword)
+}
+
+func (p *sqlParser) unexpected(token syntaxToken) *contract.Error {
+	if token.kind == tokenEOF {
+		return newSQLFragmentError("", token.column, "unexpected end of input")
+	}
+	return newSQLFragmentError(string(p.runes[token.column-1:token.end]), token.column, fmt.Sprintf("unexpected token %q", token.value))
+}
+
+// fragmentError reports the construct starting at the token, a parenthesized part that follows is included in the fragment
+func (p *sqlParser) fragmentError(start syntaxToken, message string) *contract.Error {
+	end := start.end
+	depth := 0
+	for index := p.position; index < len(p.tokens); index++ {
+		token := p.tokens[index]
+		if token.kind == sqlTokenOpenParenthesis {
+			depth++
+		}
+		if token.kind == sqlTokenCloseParenthesis {
+			depth--
+		}
+		if depth <= 0 && token.kind != sqlTokenOpenParenthesis {
+			if token.kind == sqlTokenCloseParenthesis {
+				end = token.end
+			}
+			break
+		}
+		end = token.end
+	}
+	return newSQLFragmentError(strings.TrimSpace(string(p.runes[start.column-1:end])), start.column, message)
+}
+
+// or := and ('OR' and)*
+func (p *sqlParser) parseOr() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if !p.isKeyword("OR") {
+			return mergeSQLOrNil(combineFilters(contract.FilterLogicOr, nodes)), nil
+		}
+		p.next()
+	}
+}
+
+// and := unary ('AND' unary)*
+func (p *sqlParser) parseAnd() (contract.Filters, *contract.Error) {
+	var nodes []contract.Filters
+	for {
+		node, err := p.parseUnary()
+		if err != nil {
+			return node, err
+		}
+		nodes = append(nodes, node)
+		if !p.isKeyword("AND") {
+			return combineFilters(contract.FilterLogicAnd, nodes), nil
+		}
+		p.next()
+	}
+}
+
+// unary := 'NOT' unary | '(' or ')' | predicate
+func (p *sqlParser) parseUnary() (contract.Filters, *contract.Error) {
+	if p.isKeyword("NOT") {
+		token := p.next()
+		filters, err := p.parseUnary()
+		if err != nil {
+			return filters, err
+		}
+		negated, operator, ok := negateFilters(filters)
+		if !ok {
+			fragment := strings.TrimSpace(string(p.runes[token.column-1 : p.tokens[p.position-1].end]))
+			return filters, newSQLFragmentError(fragment, token.column, fmt.Sprintf("operator %q can't be negated", operator))
+		}
+		return negated, nil
+	}
+	if p.peek().kind == sqlTokenOpenParenthesis {
+		opening := p.next()
+		if p.isKeyword("SELECT") {
+			p.position--
+			return contract.Filters{}, p.fragmentError(opening, "subqueries are not supported")
+		}
+		filters, err := p.parseOr()
+		if err != nil {
+			return filters, err
+		}
+		if closing := p.next(); closing.kind != sqlTokenCloseParenthesis {
+			return filters, p.unexpected(closing)
+		}
+		return filters, nil
+	}
+	return p.parsePredicate()
+}
+
+// predicate := column (operator literal | 'IS' ['NOT'] 'NULL' | ['NOT'] 'LIKE' string | ['NOT'] 'IN' list | ['NOT'] 'BETWEEN' literal 'AND' literal)
+func (p *sqlParser) parsePredicate() (contract.Filters, *contract.Error) {
+	column := p.next()
+	if column.kind == sqlTokenWord && p.peek().kind == sqlTokenOpenParenthesis {
+		if column.isKeyword(sqlTokenWord, "EXISTS") {
+			return contract.Filters{}, p.fragmentError(column, "subqueries are not supported")
+		}
+		return contract.Filters{}, p.fragmentError(column, fmt.Sprintf("unsupported function %q", column.value))
+	}
+	isKeyword := column.kind == sqlTokenWord && containsFold(sqlKeywords, column.value)
+	if column.kind != sqlTokenQuotedIdentifier && (column.kind != sqlTokenWord || isKeyword) {
+		if column.kind == tokenEOF || column.kind == sqlTokenCloseParenthesis || column.isKeyword(sqlTokenWord, "AND") || column.isKeyword(sqlTokenWord, "OR") {
+			return contract.Filters{}, p.unexpected(column)
+		}
+		return contract.Filters{}, newSQLFragmentError(string(p.runes[column.column-1:column.end]), column.column, "expected a column")
+	}
+	condition := contract.FilterCondition{Field: column.value}
+
+	token := p.next()
+	if token.kind == sqlTokenOperator {
+		value, err := p.parseLiteral()
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		condition.Operator = sqlOperators[token.value]
+		condition.Value = value
+		if value == "" && condition.Operator == contract.FilterOperatorEqual {
+			condition.Operator, condition.Value = contract.FilterOperatorIsEmpty, nil
+		}
+		if value == "" && condition.Operator == contract.FilterOperatorNotEqual {
+			condition.Operator, condition.Value = contract.FilterOperatorIsNotEmpty, nil
+		}
+		return newConditionFilters(condition), nil
+	}
+	if token.isKeyword(sqlTokenWord, "IS") {
+		condition.Operator = contract.FilterOperatorIsNil
+		if p.isKeyword("NOT") {
+			p.next()
+			condition.Operator = contract.FilterOperatorIsNotNil
+		}
+		if nullToken := p.next(); !nullToken.isKeyword(sqlTokenWord, "NULL") {
+			return contract.Filters{}, p.unexpected(nullToken)
+		}
+		return newConditionFilters(condition), nil
+	}
+	negated := token.isKeyword(sqlTokenWord, "NOT")
+	if negated {
+		token = p.next()
+	}
+	switch {
+	case token.isKeyword(sqlTokenWord, "ILIKE"):
+		// the filters have no case-insensitive variant of the like operators
+		return contract.Filters{}, newSQLFragmentError(token.value, token.column, "ILIKE is not supported")
+	case token.isKeyword(sqlTokenWord, "LIKE"):
+		pattern := p.next()
+		if pattern.kind != sqlTokenString {
+			return contract.Filters{}, p.unexpected(pattern)
+		}
+		if p.isKeyword("ESCAPE") {
+			escape := p.next()
+			fragment := strings.TrimSpace(string(p.runes[escape.column-1 : max(escape.end, p.peek().end)]))
+			return contract.Filters{}, newSQLFragmentError(fragment, escape.column, "ESCAPE clause is not supported")
+		}
+		return p.newLikeCondition(condition, pattern, negated)
+	case token.isKeyword(sqlTokenWord, "IN"):
+		values, err := p.parseList()
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		condition.Operator = contract.FilterOperatorIn
+		if negated {
+			condition.Operator = contract.FilterOperatorNotIn
+		}
+		condition.Value = values
+		return newConditionFilters(condition), nil
+	case token.isKeyword(sqlTokenWord, "BETWEEN"):
+		return p.parseBetween(condition, negated)
+	}
+	return contract.Filters{}, p.unexpected(token)
+}
+
+// between := literal 'AND' literal, it is converted to `column >= low AND column <= high`
+func (p *sqlParser) parseBetween(condition contract.FilterCondition, negated bool) (contract.Filters, *contract.Error) {
+	low, err := p.parseLiteral()
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	if token := p.next(); !token.isKeyword(sqlTokenWord, "AND") {
+		return contract.Filters{}, p.unexpected(token)
+	}
+	high, err := p.parseLiteral()
+	if err != nil {
+		return contract.Filters{}, err
+	}
+	lower, upper := condition, condition
+	lower.Operator, lower.Value = contract.FilterOperatorGreaterThanOrEqual, low
+	upper.Operator, upper.Value = contract.FilterOperatorLowerThanOrEqual, high
+	filters := contract.Filters{
+		Logic: contract.FilterLogicAnd,
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{lower, upper},
+		},
+	}
+	if negated {
+		filters, _, _ = negateFilters(filters)
+	}
+	return filters, nil
+}
+
+// list := '(' literal (',' literal)* ')'
+func (p *sqlParser) parseList() ([]any, *contract.Error) {
+	opening := p.next()
+	if opening.kind != sqlTokenOpenParenthesis {
+		return nil, p.unexpected(opening)
+	}
+	if p.isKeyword("SELECT") {
+		p.position--
+		return nil, p.fragmentError(opening, "subqueries are not supported")
+	}
+	values := []any{}
+	for {
+		value, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		separator := p.next()
+		if separator.kind == sqlTokenCloseParenthesis {
+			return values, nil
+		}
+		if separator.kind != sqlTokenComma {
+			return nil, p.unexpected(separator)
+		}
+	}
+}
+
+// literal := string | number | 'TRUE' | 'FALSE'
+func (p *sqlParser) parseLiteral() (any, *contract.Error) {
+	token := p.next()
+	fragment := string(p.runes[min(token.column-1, len(p.runes)):token.end])
+	switch {
+	case token.kind == sqlTokenString:
+		return token.value, nil
+	case token.kind == sqlTokenNumber:
+		number, err := strconv.ParseFloat(token.value, 64)
+		if err != nil {
+			return nil, newSQLFragmentError(fragment, token.column, "invalid number")
+		}
+		return number, nil
+	case token.isKeyword(sqlTokenWord, "TRUE"):
+		return true, nil
+	case token.isKeyword(sqlTokenWord, "FALSE"):
+		return false, nil
+	case token.isKeyword(sqlTokenWord, "NULL"):
+		return nil, newSQLFragmentError(fragment, token.column, "comparison with NULL is never true, use IS NULL instead")
+	case token.kind == sqlTokenPlaceholder:
+		return nil, newSQLFragmentError(fragment, token.column, "placeholders are not supported")
+	case token.kind == sqlTokenWord && p.peek().kind == sqlTokenOpenParenthesis:
+		return nil, p.fragmentError(token, fmt.Sprintf("unsupported function %q", token.value))
+	case token.kind == sqlTokenOpenParenthesis && p.isKeyword("SELECT"):
+		p.position--
+		return nil, p.fragmentError(token, "subqueries are not supported")
+	case token.kind == sqlTokenQuotedIdentifier || (token.kind == sqlTokenWord && !containsFold(sqlKeywords, token.value)):
+		return nil, newSQLFragmentError(fragment, token.column, "comparing columns is not supported")
+	}
+	return nil, p.unexpected(token)
+}
+
+func hasUnescapedRune(raw string, character rune) bool {
+	runes := []rune(raw)
+	for index := 0; index < len(runes); index++ {
+		if runes[index] == '\\' {
+			index++
+			continue
+		}
+		if runes[index] == character {
+			return true
+		}
+	}
+	return false
+}
+
+// newLikeCondition converts the `%` wildcards at the start and/or end of the pattern to begins, ends and contains
+func (p *sqlParser) newLikeCondition(condition contract.FilterCondition, pattern syntaxToken, negated bool) (contract.Filters, *contract.Error) {
+	fragment := strings.TrimSpace(string(p.runes[pattern.column-1 : pattern.end]))
+	value, leading, trailing, problem := parseWildcardPattern(pattern.value, '%')
+	if problem != "" || hasUnescapedRune(pattern.value, '_') {
+		return contract.Filters{}, newSQLFragmentError(fragment, pattern.column, "unsupported LIKE pattern")
+	}
+	condition.Value = value
+	switch {
+	case leading && trailing:
+		condition.Operator = contract.FilterOperatorContains
+		if negated {
+			condition.Operator = contract.FilterOperatorNotContains
+		}
+	case leading || trailing:
+		if negated {
+			return contract.Filters{}, newSQLFragmentError(fragment, pattern.column, "unsupported negated LIKE pattern")
+		}
+		condition.Operator = contract.FilterOperatorBegins
+		if leading {
+			condition.Operator = contract.FilterOperatorEnds
+		}
+	default:
+		condition.Operator = contract.FilterOperatorEqual
+		if negated {
+			condition.Operator = contract.FilterOperatorNotEqual
+		}
+	}
+	return newConditionFilters(condition), nil
+}
+
+// mergeSQLOrNil recognizes `(column >= value OR column IS NULL)` emitted for the gten and lten operators
+func mergeSQLOrNil(filters contract.Filters) contract.Filters {
+	conditions := filters.Conditions.Conditions
+	if filters.Logic != contract.FilterLogicOr || len(conditions) != 2 || len(filters.Conditions.Filters) != 0 {
+		return filters
+	}
+	condition, missing := conditions[0], conditions[1]
+	if missing.Operator != contract.FilterOperatorIsNil || missing.Field != condition.Field {
+		return filters
+	}
+	switch condition.Operator {
+	case contract.FilterOperatorGreaterThanOrEqual:
+		condition.Operator = contract.FilterOperatorGreaterThanOrEqualOrNil
+	case contract.FilterOperatorLowerThanOrEqual:
+		condition.Operator = contract.FilterOperatorLowerThanOrEqualOrNil
+	default:
+		return filters
+	}
+	return newConditionFilters(condition)
+}
+
+type SQLInput struct {
+	contract.InputOutputType[string]
+}
+
+func (i *SQLInput) GetDataString() (string, error) {
+	return i.GetData()
+}
+
+func (i *SQLInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == "" {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+type SQLInputTransformer struct {
+}
+
+func (t *SQLInputTransformer) Transform(input *SQLInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	if strings.TrimSpace(rawData) == "" {
+		return filters, nil
+	}
+	runes := []rune(rawData)
+	tokens, transformErr := lexSQL(runes)
+	if transformErr != nil {
+		return filters, transformErr
+	}
+	parser := sqlParser{runes: runes, tokenCursor: tokenCursor{tokens: tokens}}
+	// the predicate may be copied including the `WHERE` keyword
+	if parser.isKeyword("WHERE") {
+		parser.next()
+	}
+	filters, transformErr = parser.parseOr()
+	if transformErr != nil {
+		return contract.Filters{}, transformErr
+	}
+	if token := parser.peek(); token.kind != tokenEOF {
+		return contract.Filters{}, parser.unexpected(token)
+	}
+	return filters, nil
+}
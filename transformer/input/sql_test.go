@@ -0,0 +1,272 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+func TestSQLInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   " ",
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "example from a report definition",
+			input: `a = 1 AND (b LIKE 'x%' OR c IS NULL) AND d IN (1,2)`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorEqual, Value: 1.0},
+						{Field: "d", Operator: contract.FilterOperatorIn, Value: []any{1.0, 2.0}},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "b", Operator: contract.FilterOperatorBegins, Value: "x"},
+									{Field: "c", Operator: contract.FilterOperatorIsNil},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "all operators",
+			input: `WHERE a <> 'O''Brien' AND b > -1.5 AND c >= 2 AND d < 3 AND e <= 4 AND f LIKE '%x%' AND g NOT LIKE '%y%' AND h LIKE '%z' AND i LIKE 'exact' AND j IS NOT NULL AND k = '' AND l != '' AND m NOT IN ('a', 'b') AND "my column" = true AND [t].[n] = FALSE AND t.o >= 5 OR t.o IS NULL`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "t.o", Operator: contract.FilterOperatorIsNil},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "a", Operator: contract.FilterOperatorNotEqual, Value: "O'Brien"},
+									{Field: "b", Operator: contract.FilterOperatorGreaterThan, Value: -1.5},
+									{Field: "c", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 2.0},
+									{Field: "d", Operator: contract.FilterOperatorLowerThan, Value: 3.0},
+									{Field: "e", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 4.0},
+									{Field: "f", Operator: contract.FilterOperatorContains, Value: "x"},
+									{Field: "g", Operator: contract.FilterOperatorNotContains, Value: "y"},
+									{Field: "h", Operator: contract.FilterOperatorEnds, Value: "z"},
+									{Field: "i", Operator: contract.FilterOperatorEqual, Value: "exact"},
+									{Field: "j", Operator: contract.FilterOperatorIsNotNil},
+									{Field: "k", Operator: contract.FilterOperatorIsEmpty},
+									{Field: "l", Operator: contract.FilterOperatorIsNotEmpty},
+									{Field: "m", Operator: contract.FilterOperatorNotIn, Value: []any{"a", "b"}},
+									{Field: "my column", Operator: contract.FilterOperatorEqual, Value: true},
+									{Field: "t.n", Operator: contract.FilterOperatorEqual, Value: false},
+									{Field: "t.o", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 5.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "output of the SQL output transformer",
+			input: `((key = 'val' AND key2 != '') OR (key3 LIKE '%val3%' AND (key4 >= 123 OR key4 IS NULL)))`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+									{Field: "key2", Operator: contract.FilterOperatorIsNotEmpty},
+								},
+							},
+						},
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key3", Operator: contract.FilterOperatorContains, Value: "val3"},
+									{Field: "key4", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 123.0},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "between and negation",
+			input: `price BETWEEN 10 AND 20 AND NOT (status = 'a' OR qty NOT BETWEEN 1 AND 2)`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "price", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 10.0},
+						{Field: "price", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 20.0},
+						{Field: "status", Operator: contract.FilterOperatorNotEqual, Value: "a"},
+						{Field: "qty", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 1.0},
+						{Field: "qty", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 2.0},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - function",
+			input:   `a = 1 AND lower(name) = 'x'`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "lower(name)", Column: 11, Error: `unsupported function "lower"`}),
+		},
+		{
+			name:    "invalid input - function as a value",
+			input:   `created > now()`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "now()", Column: 11, Error: `unsupported function "now"`}),
+		},
+		{
+			name:    "invalid input - subquery",
+			input:   `id IN (SELECT user_id FROM orders WHERE total > (10 + 5)) AND a = 1`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "(SELECT user_id FROM orders WHERE total > (10 + 5))", Column: 7, Error: "subqueries are not supported"}),
+		},
+		{
+			name:    "invalid input - exists",
+			input:   `EXISTS (SELECT 1 FROM orders)`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "EXISTS (SELECT 1 FROM orders)", Column: 1, Error: "subqueries are not supported"}),
+		},
+		{
+			name:    "invalid input - comparison of columns",
+			input:   `a = b`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "b", Column: 5, Error: "comparing columns is not supported"}),
+		},
+		{
+			name:    "invalid input - placeholder",
+			input:   `a = $1`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "$1", Column: 5, Error: "placeholders are not supported"}),
+		},
+		{
+			name:    "invalid input - comparison with null",
+			input:   `a = NULL`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "NULL", Column: 5, Error: "comparison with NULL is never true, use IS NULL instead"}),
+		},
+		{
+			name:    "invalid input - like pattern",
+			input:   `a LIKE 'x_y%'`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "'x_y%'", Column: 8, Error: "unsupported LIKE pattern"}),
+		},
+		{
+			name:    "invalid input - like pattern without a value",
+			input:   `a LIKE '%'`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "'%'", Column: 8, Error: "unsupported LIKE pattern"}),
+		},
+		{
+			name:    "invalid input - like pattern of wildcards only",
+			input:   `a LIKE '%%'`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "'%%'", Column: 8, Error: "unsupported LIKE pattern"}),
+		},
+		{
+			name:    "invalid input - ilike",
+			input:   `a = 1 AND b NOT ilike '%x%'`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "ilike", Column: 17, Error: "ILIKE is not supported"}),
+		},
+		{
+			name:    "invalid input - negated prefix",
+			input:   `NOT a LIKE 'x%'`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "NOT a LIKE 'x%'", Column: 1, Error: `operator "begins" can't be negated`}),
+		},
+		{
+			name:    "invalid input - arithmetic",
+			input:   `a + 1 = 2`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "+", Column: 3, Error: `unexpected token "+"`}),
+		},
+		{
+			name:    "invalid input - unterminated string",
+			input:   `a = 'x`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Fragment: "'x", Column: 5, Error: "unterminated string"}),
+		},
+		{
+			name:    "invalid input - missing closing parenthesis",
+			input:   `(a = 1`,
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, SQLFragmentError{Column: 7, Error: "unexpected end of input"}),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := SQLInputTransformer{}
+			input, _ := contract.NewInputOutputType(tt.input, &SQLInput{})
+			got, err := t.Transform(input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   "a = 'x'",
+			want:    []byte(`"a = 'x'"`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType(tt.input, &SQLInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+var numberLiteralPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+func newSyntaxError(message string, column int) *contract.Error {
+	return contract.NewError(contract.InvalidInputDataStructure, fmt.Sprintf("%s at column %d", message, column))
+}
+
+func newUnexpectedTokenError(token string, column int) *contract.Error {
+	if token == "" {
+		return newSyntaxError("unexpected end of input", column)
+	}
+	return newSyntaxError(fmt.Sprintf("unexpected token %q", token), column)
+}
+
+// tokenKind is enumerated by each lexer, the zero value (tokenEOF) ends every list of tokens
+type tokenKind int
+
+const tokenEOF tokenKind = 0
+
+type syntaxToken struct {
+	kind   tokenKind
+	value  string
+	column int
+	// verbatim (quoted or escaped) text is never converted to a number/bool/null literal
+	verbatim bool
+	// end is the offset (in runes) right after the token, it is only set by the lexers that report fragments of the input
+	end int
+}
+
+func (t syntaxToken) unexpected() *contract.Error {
+	return newUnexpectedTokenError(t.value, t.column)
+}
+
+// isKeyword matches the value case-insensitively (e.g. `and` as well as `AND`)
+func (t syntaxToken) isKeyword(kind tokenKind, keyword string) bool {
+	return t.kind == kind && strings.EqualFold(t.value, keyword)
+}
+
+// tokenCursor is embedded by the parsers, it never moves past the end of input
+type tokenCursor struct {
+	tokens   []syntaxToken
+	position int
+}
+
+func (c *tokenCursor) peek() syntaxToken {
+	return c.tokens[c.position]
+}
+
+func (c *tokenCursor) next() syntaxToken {
+	token := c.tokens[c.position]
+	if token.kind != tokenEOF {
+		c.position++
+	}
+	return token
+}
+
+// expect consumes the next token, a token of another kind is reported as unexpected
+func (c *tokenCursor) expect(kind tokenKind) (syntaxToken, *contract.Error) {
+	token := c.next()
+	if token.kind != kind {
+		return token, token.unexpected()
+	}
+	return token, nil
+}
+
+// expectDescribed is like expect, the error describes the expected token instead (e.g. `expected ":", got end of input`)
+func (c *tokenCursor) expectDescribed(kind tokenKind, message string) (syntaxToken, *contract.Error) {
+	token := c.next()
+	if token.kind == kind {
+		return token, nil
+	}
+	if token.kind == tokenEOF {
+		return token, newSyntaxError(message+", got end of input", token.column)
+	}
+	return token, newSyntaxError(fmt.Sprintf("%s, got %q", message, token.value), token.column)
+}
+
+// expectEnd fails if the parser stopped before the end of input
+func (c *tokenCursor) expectEnd() *contract.Error {
+	if token := c.peek(); token.kind != tokenEOF {
+		return token.unexpected()
+	}
+	return nil
+}
+
+func unescapeBackslashes(raw string) string {
+	if !strings.ContainsRune(raw, '\\') {
+		return raw
+	}
+	runes := []rune(raw)
+	var builder strings.Builder
+	for index := 0; index < len(runes); index++ {
+		if runes[index] == '\\' && index+1 < len(runes) {
+			index++
+		}
+		builder.WriteRune(runes[index])
+	}
+	return builder.String()
+}
+
+// parseWildcardPattern unescapes the value and detects leading and trailing wildcards (e.g. `*` or `%`);
+// problem describes why the pattern can't be expressed using begins, ends and contains (empty if it can)
+func parseWildcardPattern(raw string, wildcard rune) (value string, leading bool, trailing bool, problem string) {
+	runes := []rune(raw)
+	var builder strings.Builder
+	for index := 0; index < len(runes); index++ {
+		character := runes[index]
+		if character == '\\' && index+1 < len(runes) {
+			index++
+			builder.WriteRune(runes[index])
+			continue
+		}
+		if character == wildcard {
+			switch {
+			case index == 0:
+				leading = true
+			case index == len(runes)-1:
+				trailing = true
+			default:
+				problem = "unsupported wildcard position"
+			}
+			continue
+		}
+		builder.WriteRune(character)
+	}
+	value = builder.String()
+	if problem == "" && (leading || trailing) && value == "" {
+		// e.g. `*` would become begins/contains with an empty value (use not-null instead)
+		problem = "wildcard without a value"
+	}
+	return value, leading, trailing, problem
+}
+
+// parseLiteral converts an unquoted literal the same way JSON decoding would (numbers are float64)
+func parseLiteral(text string) any {
+	switch text {
+	case "null":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if numberLiteralPattern.MatchString(text) {
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number
+		}
+	}
+	return text
+}
+
+func newConditionFilters(condition contract.FilterCondition) contract.Filters {
+	return contract.Filters{
+		Logic: contract.FilterLogicAnd,
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{condition},
+		},
+	}
+}
+
+// combineFilters joins the nodes using the logic; single conditions are lifted
+// and nodes using the same logic are flattened into the result
+func combineFilters(logic contract.FilterLogic, nodes []contract.Filters) contract.Filters {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+	combined := contract.Filters{Logic: logic}
+	for _, node := range nodes {
+		isSingleCondition := len(node.Conditions.Conditions) == 1 && len(node.Conditions.Filters) == 0
+		if isSingleCondition || node.Logic == logic {
+			combined.Conditions.Conditions = append(combined.Conditions.Conditions, node.Conditions.Conditions...)
+			combined.Conditions.Filters = append(combined.Conditions.Filters, node.Conditions.Filters...)
+			continue
+		}
+		combined.Conditions.Filters = append(combined.Conditions.Filters, node)
+	}
+	return combined
+}
+
+var negatedOperators = map[contract.FilterOperator]contract.FilterOperator{
+	contract.FilterOperatorEqual:              contract.FilterOperatorNotEqual,
+	contract.FilterOperatorNotEqual:           contract.FilterOperatorEqual,
+	contract.FilterOperatorGreaterThan:        contract.FilterOperatorLowerThanOrEqual,
+	contract.FilterOperatorLowerThanOrEqual:   contract.FilterOperatorGreaterThan,
+	contract.FilterOperatorGreaterThanOrEqual: contract.FilterOperatorLowerThan,
+	contract.FilterOperatorLowerThan:          contract.FilterOperatorGreaterThanOrEqual,
+	contract.FilterOperatorContains:           contract.FilterOperatorNotContains,
+	contract.FilterOperatorNotContains:        contract.FilterOperatorContains,
+	contract.FilterOperatorIsNil:              contract.FilterOperatorIsNotNil,
+	contract.FilterOperatorIsNotNil:           contract.FilterOperatorIsNil,
+	contract.FilterOperatorIsEmpty:            contract.FilterOperatorIsNotEmpty,
+	contract.FilterOperatorIsNotEmpty:         contract.FilterOperatorIsEmpty,
+	contract.FilterOperatorIn:                 contract.FilterOperatorNotIn,
+	contract.FilterOperatorNotIn:              contract.FilterOperatorIn,
+}
+
+// negateFilters applies De Morgan's laws to the filters; it fails with the first operator that has no negation
+func negateFilters(filters contract.Filters) (contract.Filters, contract.FilterOperator, bool) {
+	negated := contract.Filters{Logic: contract.FilterLogicOr}
+	if filters.Logic == contract.FilterLogicOr {
+		negated.Logic = contract.FilterLogicAnd
+	}
+	for _, condition := range filters.Conditions.Conditions {
+		operator, hasNegation := negatedOperators[condition.Operator]
+		if !hasNegation {
+			return filters, condition.Operator, false
+		}
+		condition.Operator = operator
+		negated.Conditions.Conditions = append(negated.Conditions.Conditions, condition)
+	}
+	for _, nested := range filters.Conditions.Filters {
+		negatedNested, operator, ok := negateFilters(nested)
+		if !ok {
+			return filters, operator, false
+		}
+		negated.Conditions.Filters = append(negated.Conditions.Filters, negatedNested)
+	}
+	if len(negated.Conditions.Conditions) == 1 && len(negated.Conditions.Filters) == 0 {
+		negated.Logic = contract.FilterLogicAnd
+	}
+	return negated, "", true
+}
+
+// newJSONCondition builds the condition the same way JSON decoding would (e.g. in/not-in values are split by commas)
+func newJSONCondition(field string, operator contract.FilterOperator, value any) (contract.FilterCondition, error) {
+	var condition contract.FilterCondition
+	rawData, err := json.Marshal(map[string]any{"field": field, "operator": operator, "value": value})
+	if err != nil {
+		return condition, err
+	}
+	err = json.Unmarshal(rawData, &condition)
+	return condition, err
+}
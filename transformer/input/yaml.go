@@ -0,0 +1,232 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"gopkg.in/yaml.v3"
+)
+
+var yamlSyntaxErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// YAMLNodeError is the payload of the error returned for an invalid part of the document;
+// Path uses the same format as contract.ValidationError (e.g. `root.conditions.1.operator`)
+type YAMLNodeError struct {
+	Path   string
+	Line   int
+	Column int
+	Error  string
+}
+
+func newYAMLNodeError(path string, node *yaml.Node, message string) *contract.Error {
+	return contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{
+		Path:   path,
+		Line:   node.Line,
+		Column: node.Column,
+		Error:  message,
+	})
+}
+
+// newYAMLSyntaxError extracts the line from the parser error, the column is not reported by the parser
+func newYAMLSyntaxError(err error) *contract.Error {
+	payload := YAMLNodeError{Error: err.Error()}
+	if matches := yamlSyntaxErrorPattern.FindStringSubmatch(err.Error()); matches != nil {
+		payload.Line, _ = strconv.Atoi(matches[1])
+		payload.Error = matches[2]
+	}
+	return contract.NewError(contract.InvalidInputDataStructure, payload)
+}
+
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// yamlMapping maps the lower-cased keys of the mapping to their key and value nodes (keys are case-insensitive like in JSON)
+func yamlMapping(node *yaml.Node, path string, allowedKeys []string) (map[string][2]*yaml.Node, *contract.Error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, newYAMLNodeError(path, node, "expected a mapping")
+	}
+	mapping := make(map[string][2]*yaml.Node, len(node.Content)/2)
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		keyNode, valueNode := resolveYAMLAlias(node.Content[index]), resolveYAMLAlias(node.Content[index+1])
+		key := strings.ToLower(keyNode.Value)
+		if keyNode.Kind != yaml.ScalarNode || !slices.Contains(allowedKeys, key) {
+			return nil, newYAMLNodeError(path, keyNode, fmt.Sprintf("unknown key %q, expected one of %s", keyNode.Value, strings.Join(allowedKeys, ", ")))
+		}
+		if previous, isDuplicate := mapping[key]; isDuplicate {
+			return nil, newYAMLNodeError(fmt.Sprintf("%s.%s", path, key), keyNode, fmt.Sprintf("duplicate key %q (already defined at line %d)", keyNode.Value, previous[0].Line))
+		}
+		mapping[key] = [2]*yaml.Node{keyNode, valueNode}
+	}
+	return mapping, nil
+}
+
+func isYAMLGroup(node *yaml.Node) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	for index := 0; index < len(node.Content); index += 2 {
+		key := strings.ToLower(resolveYAMLAlias(node.Content[index]).Value)
+		if key == "logic" || key == "conditions" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseYAMLFilters reads `{logic: and|or, conditions: [...]}`, the conditions are either nested groups or conditions
+func parseYAMLFilters(node *yaml.Node, path string) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	mapping, err := yamlMapping(node, path, []string{"logic", "conditions"})
+	if err != nil {
+		return filters, err
+	}
+	if logic, hasLogic := mapping["logic"]; hasLogic {
+		filters.Logic = contract.FilterLogic(logic[1].Value)
+		if logic[1].Kind != yaml.ScalarNode || (filters.Logic != contract.FilterLogicAnd && filters.Logic != contract.FilterLogicOr) {
+			return contract.Filters{}, newYAMLNodeError(fmt.Sprintf("%s.logic", path), logic[1], fmt.Sprintf("unsupported logic %q, expected and or or", logic[1].Value))
+		}
+	}
+	conditions, hasConditions := mapping["conditions"]
+	if !hasConditions {
+		return contract.Filters{}, newYAMLNodeError(fmt.Sprintf("%s.conditions", path), node, "missing conditions")
+	}
+	if conditions[1].Kind != yaml.SequenceNode || len(conditions[1].Content) == 0 {
+		return contract.Filters{}, newYAMLNodeError(fmt.Sprintf("%s.conditions", path), conditions[1], "expected a non-empty list of conditions")
+	}
+	for index, item := range conditions[1].Content {
+		item = resolveYAMLAlias(item)
+		itemPath := fmt.Sprintf("%s.conditions.%d", path, index)
+		if isYAMLGroup(item) {
+			nested, err := parseYAMLFilters(item, itemPath)
+			if err != nil {
+				return contract.Filters{}, err
+			}
+			filters.Conditions.Filters = append(filters.Conditions.Filters, nested)
+			continue
+		}
+		condition, err := parseYAMLCondition(item, itemPath)
+		if err != nil {
+			return contract.Filters{}, err
+		}
+		filters.Conditions.Conditions = append(filters.Conditions.Conditions, condition)
+	}
+	return filters, nil
+}
+
+func parseYAMLCondition(node *yaml.Node, path string) (contract.FilterCondition, *contract.Error) {
+	var condition contract.FilterCondition
+	mapping, err := yamlMapping(node, path, []string{"field", "operator", "value"})
+	if err != nil {
+		return condition, err
+	}
+	field, hasField := mapping["field"]
+	if !hasField {
+		return condition, newYAMLNodeError(fmt.Sprintf("%s.field", path), node, "missing field")
+	}
+	if field[1].Kind != yaml.ScalarNode || field[1].Tag == "!!null" || field[1].Value == "" {
+		return condition, newYAMLNodeError(fmt.Sprintf("%s.field", path), field[1], "expected a non-empty field name")
+	}
+	operator, hasOperator := mapping["operator"]
+	if !hasOperator {
+		return condition, newYAMLNodeError(fmt.Sprintf("%s.operator", path), node, "missing operator")
+	}
+	filterOperator := contract.FilterOperator(operator[1].Value)
+	if operator[1].Kind != yaml.ScalarNode || !slices.Contains(contract.SupportedOperators(), filterOperator) {
+		return condition, newYAMLNodeError(fmt.Sprintf("%s.operator", path), operator[1], fmt.Sprintf("unsupported operator %q", operator[1].Value))
+	}
+	var value any
+	if valueNode, hasValue := mapping["value"]; hasValue {
+		var decodeErr error
+		value, decodeErr = decodeYAMLValue(valueNode[1])
+		if decodeErr != nil {
+			return condition, newYAMLNodeError(fmt.Sprintf("%s.value", path), valueNode[1], decodeErr.Error())
+		}
+	}
+	condition, conditionErr := newJSONCondition(field[1].Value, filterOperator, value)
+	if conditionErr != nil {
+		return condition, newYAMLNodeError(fmt.Sprintf("%s.value", path), node, conditionErr.Error())
+	}
+	return condition, nil
+}
+
+// decodeYAMLValue keeps timestamps as written (they would be decoded as time.Time otherwise)
+func decodeYAMLValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!timestamp" {
+			return node.Value, nil
+		}
+	case yaml.SequenceNode:
+		values := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			value, err := decodeYAMLValue(resolveYAMLAlias(item))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case yaml.MappingNode:
+		return nil, fmt.Errorf("expected a scalar or a list of scalars")
+	}
+	var value any
+	err := node.Decode(&value)
+	return value, err
+}
+
+type YAMLInput struct {
+	contract.InputOutputType[[]byte]
+}
+
+func (i *YAMLInput) GetDataString() (string, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return "", err
+	}
+	return string(rawData), nil
+}
+
+func (i *YAMLInput) GetDataJson() ([]byte, error) {
+	rawData, err := i.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(rawData)) == 0 {
+		return nil, nil
+	}
+	var document any
+	if err := yaml.Unmarshal(rawData, &document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+type YAMLInputTransformer struct {
+}
+
+func (t *YAMLInputTransformer) Transform(input *YAMLInput) (contract.Filters, *contract.Error) {
+	var filters contract.Filters
+	rawData, err := input.GetData()
+	if err != nil {
+		return filters, contract.NewError(contract.UnreadableInputData, err.Error())
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(rawData, &document); err != nil {
+		return filters, newYAMLSyntaxError(err)
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		// empty document or comments only
+		return filters, nil
+	}
+	return parseYAMLFilters(resolveYAMLAlias(document.Content[0]), "root")
+}
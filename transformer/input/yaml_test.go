@@ -0,0 +1,209 @@
+package input
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+const testInputYAMLDashboard = `# default filters of the sales dashboard
+logic: and
+conditions:
+  - field: created
+    operator: gte
+    value: 2024-01-01
+  - field: tags
+    operator: in
+    value: a, b
+  - logic: or
+    conditions:
+      - field: price
+        operator: gt
+        value: 10
+      - field: price
+        operator: nil
+`
+
+func TestYAMLInputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    contract.Filters
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   "# nothing here\n",
+			want:    contract.Filters{},
+			wantErr: nil,
+		},
+		{
+			name:  "input with nested data",
+			input: testInputYAMLDashboard,
+			want: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "created", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: "2024-01-01"},
+						{Field: "tags", Operator: contract.FilterOperatorIn, Value: []string{"a", "b"}},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "price", Operator: contract.FilterOperatorGreaterThan, Value: 10.0},
+									{Field: "price", Operator: contract.FilterOperatorIsNil},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "anchors",
+			input: `conditions:
+  - {field: status, operator: in, value: &statuses [active, trial]}
+  - {field: previous_status, operator: not-in, value: *statuses}
+`,
+			want: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "status", Operator: contract.FilterOperatorIn, Value: []any{"active", "trial"}},
+						{Field: "previous_status", Operator: contract.FilterOperatorNotIn, Value: []any{"active", "trial"}},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "case-insensitive keys and lists",
+			input: `Logic: or
+Conditions:
+  - {field: status, operator: in, value: [active, "trial", 1]}
+  - {field: flagged, operator: eq, value: true}
+`,
+			want: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "status", Operator: contract.FilterOperatorIn, Value: []any{"active", "trial", 1.0}},
+						{Field: "flagged", Operator: contract.FilterOperatorEqual, Value: true},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "invalid input - syntax error",
+			input:   "logic: and\nconditions: [\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Line: 2, Error: "did not find expected node content"}),
+		},
+		{
+			name:    "invalid input - unsupported operator",
+			input:   "logic: and\nconditions:\n  - field: price\n    operator: gtx\n    value: 10\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root.conditions.0.operator", Line: 4, Column: 15, Error: `unsupported operator "gtx"`}),
+		},
+		{
+			name:    "invalid input - unsupported logic",
+			input:   "logic: and\nconditions:\n  - logic: xor\n    conditions:\n      - {field: a, operator: eq, value: 1}\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root.conditions.0.logic", Line: 3, Column: 12, Error: `unsupported logic "xor", expected and or or`}),
+		},
+		{
+			name:    "invalid input - missing field",
+			input:   "conditions:\n  - {field: a, operator: eq}\n  - operator: eq\n    value: 1\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root.conditions.1.field", Line: 3, Column: 5, Error: "missing field"}),
+		},
+		{
+			name:    "invalid input - typo in key",
+			input:   "logic: and\nconditons:\n  - {field: a, operator: eq, value: 1}\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root", Line: 2, Column: 1, Error: `unknown key "conditons", expected one of logic, conditions`}),
+		},
+		{
+			name:    "invalid input - empty conditions",
+			input:   "logic: and\nconditions: []\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root.conditions", Line: 2, Column: 13, Error: "expected a non-empty list of conditions"}),
+		},
+		{
+			name:    "invalid input - duplicate key",
+			input:   "conditions:\n  - field: a\n    operator: eq\n    field: b\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root.conditions.0.field", Line: 4, Column: 5, Error: `duplicate key "field" (already defined at line 2)`}),
+		},
+		{
+			name:    "invalid input - mapping as value",
+			input:   "conditions:\n  - field: a\n    operator: eq\n    value: {b: 1}\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root.conditions.0.value", Line: 4, Column: 12, Error: "expected a scalar or a list of scalars"}),
+		},
+		{
+			name:    "invalid input - not a mapping",
+			input:   "- field: a\n",
+			want:    contract.Filters{},
+			wantErr: contract.NewError(contract.InvalidInputDataStructure, YAMLNodeError{Path: "root", Line: 1, Column: 1, Error: "expected a mapping"}),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := YAMLInputTransformer{}
+			input, _ := contract.NewInputOutputType([]byte(tt.input), &YAMLInput{})
+			got, err := t.Transform(input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLInput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "input with data",
+			input:   "logic: and\nconditions:\n  - {field: key, operator: eq, value: val}\n",
+			want:    []byte(`{"conditions":[{"field":"key","operator":"eq","value":"val"}],"logic":"and"}`),
+			wantErr: false,
+		},
+		{
+			name:    "invalid input",
+			input:   "logic: [",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, _ := contract.NewInputOutputType([]byte(tt.input), &YAMLInput{})
+			got, err := input.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
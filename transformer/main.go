@@ -10,6 +10,8 @@ type FilterTransformer[IDT any, ODT any, IT contract.InputOutputInterface[IDT],
 	inputTransformer  contract.InputTransformerInterface[IDT, IT]
 	outputTransformer contract.OutputTransformerInterface[ODT, OT]
 	validationFunc    *contract.ValidationFunc
+	fieldMapping      contract.FieldMapping
+	schema            contract.Schema
 }
 
 func (t *FilterTransformer[IDT, ODT, IT, OT]) Transform(input IT) (o OT, err *contract.Error) {
@@ -22,6 +24,20 @@ func (t *FilterTransformer[IDT, ODT, IT, OT]) Transform(input IT) (o OT, err *co
 		err = contract.NewError(contract.InvalidFiltersStructure, validationErrors)
 		return
 	}
+	if t.schema != nil {
+		filter, validationErrors = t.schema.Coerce(filter)
+		if len(validationErrors) > 0 {
+			err = contract.NewError(contract.InvalidFiltersStructure, validationErrors)
+			return
+		}
+	}
+	if t.fieldMapping != nil {
+		filter, validationErrors = filter.MapFields(t.fieldMapping)
+		if len(validationErrors) > 0 {
+			err = contract.NewError(contract.InvalidFiltersStructure, validationErrors)
+			return
+		}
+	}
 	o, err = t.outputTransformer.Transform(filter)
 	return
 }
@@ -31,6 +47,20 @@ func (t *FilterTransformer[IDT, ODT, IT, OT]) WithValidationFunc(validationFunc
 	return t
 }
 
+// WithFieldMapping renames the fields of the filters to the fields of the backend after the validation (so the validation
+// function receives the public fields), fields missing in the mapping are rejected
+func (t *FilterTransformer[IDT, ODT, IT, OT]) WithFieldMapping(fieldMapping contract.FieldMapping) *FilterTransformer[IDT, ODT, IT, OT] {
+	t.fieldMapping = fieldMapping
+	return t
+}
+
+// WithSchema validates the filters against the schema and converts the values to the types of the fields before the field
+// mapping (so the schema uses the public fields), values that can't be converted are rejected
+func (t *FilterTransformer[IDT, ODT, IT, OT]) WithSchema(schema contract.Schema) *FilterTransformer[IDT, ODT, IT, OT] {
+	t.schema = schema
+	return t
+}
+
 func NewFilterTransformer[IDT any, ODT any, IT contract.InputOutputInterface[IDT], OT contract.InputOutputInterface[ODT]](
 	inputTransformer contract.InputTransformerInterface[IDT, IT],
 	outputTransformer contract.OutputTransformerInterface[ODT, OT],
@@ -55,5 +85,74 @@ func NewJsonToSQLFilterTransformer() *FilterTransformer[[]byte, output.SQLTuple,
 	return NewFilterTransformer[[]byte, output.SQLTuple, *input.JsonInput, *output.SQLOutput](&it, &ot, nil)
 }
 
-// TODO: NewFormDataToElasticFilterTransformer
-// TODO: NewFormDataToSQLFilterTransformer
+func NewJsonToMongoFilterTransformer() *FilterTransformer[[]byte, map[string]any, *input.JsonInput, *output.MongoOutput] {
+	it := input.JsonInputTransformer{}
+	ot := output.MongoOutputTransformer{}
+	return NewFilterTransformer[[]byte, map[string]any, *input.JsonInput, *output.MongoOutput](&it, &ot, nil)
+}
+
+func NewJsonToPredicateFilterTransformer() *FilterTransformer[[]byte, output.Predicate, *input.JsonInput, *output.PredicateOutput] {
+	it := input.JsonInputTransformer{}
+	ot := output.PredicateOutputTransformer{}
+	return NewFilterTransformer[[]byte, output.Predicate, *input.JsonInput, *output.PredicateOutput](&it, &ot, nil)
+}
+
+func NewFormDataToElasticFilterTransformer() *FilterTransformer[map[string][]string, map[string]any, *input.FormDataInput, *output.ElasticOutput] {
+	it := input.FormDataInputTransformer{}
+	ot := output.ElasticOutputTransformer{}
+	return NewFilterTransformer[map[string][]string, map[string]any, *input.FormDataInput, *output.ElasticOutput](&it, &ot, nil)
+}
+
+func NewFormDataToSQLFilterTransformer() *FilterTransformer[map[string][]string, output.SQLTuple, *input.FormDataInput, *output.SQLOutput] {
+	it := input.FormDataInputTransformer{}
+	ot := output.SQLOutputTransformer{}
+	return NewFilterTransformer[map[string][]string, output.SQLTuple, *input.FormDataInput, *output.SQLOutput](&it, &ot, nil)
+}
+
+func NewFormDataToMongoFilterTransformer() *FilterTransformer[map[string][]string, map[string]any, *input.FormDataInput, *output.MongoOutput] {
+	it := input.FormDataInputTransformer{}
+	ot := output.MongoOutputTransformer{}
+	return NewFilterTransformer[map[string][]string, map[string]any, *input.FormDataInput, *output.MongoOutput](&it, &ot, nil)
+}
+
+func NewJsonToExpressionFilterTransformer() *FilterTransformer[[]byte, string, *input.JsonInput, *output.ExpressionOutput] {
+	it := input.JsonInputTransformer{}
+	ot := output.ExpressionOutputTransformer{}
+	return NewFilterTransformer[[]byte, string, *input.JsonInput, *output.ExpressionOutput](&it, &ot, nil)
+}
+
+func NewElasticToSQLFilterTransformer() *FilterTransformer[map[string]any, output.SQLTuple, *input.ElasticInput, *output.SQLOutput] {
+	it := input.ElasticInputTransformer{}
+	ot := output.SQLOutputTransformer{}
+	return NewFilterTransformer[map[string]any, output.SQLTuple, *input.ElasticInput, *output.SQLOutput](&it, &ot, nil)
+}
+
+func NewSQLToElasticFilterTransformer() *FilterTransformer[string, map[string]any, *input.SQLInput, *output.ElasticOutput] {
+	it := input.SQLInputTransformer{}
+	ot := output.ElasticOutputTransformer{}
+	return NewFilterTransformer[string, map[string]any, *input.SQLInput, *output.ElasticOutput](&it, &ot, nil)
+}
+
+func NewMongoToElasticFilterTransformer() *FilterTransformer[map[string]any, map[string]any, *input.MongoInput, *output.ElasticOutput] {
+	it := input.MongoInputTransformer{}
+	ot := output.ElasticOutputTransformer{}
+	return NewFilterTransformer[map[string]any, map[string]any, *input.MongoInput, *output.ElasticOutput](&it, &ot, nil)
+}
+
+func NewMongoToSQLFilterTransformer() *FilterTransformer[map[string]any, output.SQLTuple, *input.MongoInput, *output.SQLOutput] {
+	it := input.MongoInputTransformer{}
+	ot := output.SQLOutputTransformer{}
+	return NewFilterTransformer[map[string]any, output.SQLTuple, *input.MongoInput, *output.SQLOutput](&it, &ot, nil)
+}
+
+func NewYAMLToElasticFilterTransformer() *FilterTransformer[[]byte, map[string]any, *input.YAMLInput, *output.ElasticOutput] {
+	it := input.YAMLInputTransformer{}
+	ot := output.ElasticOutputTransformer{}
+	return NewFilterTransformer[[]byte, map[string]any, *input.YAMLInput, *output.ElasticOutput](&it, &ot, nil)
+}
+
+func NewYAMLToSQLFilterTransformer() *FilterTransformer[[]byte, output.SQLTuple, *input.YAMLInput, *output.SQLOutput] {
+	it := input.YAMLInputTransformer{}
+	ot := output.SQLOutputTransformer{}
+	return NewFilterTransformer[[]byte, output.SQLTuple, *input.YAMLInput, *output.SQLOutput](&it, &ot, nil)
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/wernerdweight/filter-transformer-go/transformer/output"
 	"log"
 	"reflect"
+	"slices"
 	"testing"
+	"time"
 )
 
 var testInputJson0, _ = contract.NewInputOutputType([]byte(`{"logic": "and", "conditions": [{"field": "key", "operator": "eq", "value": "val"}]}`), &input.JsonInput{})
@@ -44,11 +46,18 @@ var testOutputElastic7, _ = contract.NewInputOutputType(map[string]any{"bool": m
 var testOutputElastic8, _ = contract.NewInputOutputType(map[string]any{"bool": map[string]any{"must": []map[string]any{{"terms": map[string]any{"key.lowersortable": []string{"val", "val2"}}}}}}, &output.ElasticOutput{})
 var testOutputElastic9, _ = contract.NewInputOutputType(map[string]any{"bool": map[string]any{"must": []map[string]any{{"terms": map[string]any{"key.lowersortable": []any{"val", "val2"}}}}}}, &output.ElasticOutput{})
 
-var testOutputSQL0, _ = contract.NewInputOutputType(output.SQLTuple{Query: "key = $1", Params: []any{"val"}}, &output.SQLOutput{})
-var testOutputSQL1, _ = contract.NewInputOutputType(output.SQLTuple{Query: "(key = $1 OR key2 != $2)", Params: []any{"val", "val2"}}, &output.SQLOutput{})
-var testOutputSQL2, _ = contract.NewInputOutputType(output.SQLTuple{Query: "key IS NOT NULL", Params: nil}, &output.SQLOutput{})
-var testOutputSQL3, _ = contract.NewInputOutputType(output.SQLTuple{Query: "key >= $1", Params: []any{123.0}}, &output.SQLOutput{})
-var testOutputSQL4, _ = contract.NewInputOutputType(output.SQLTuple{Query: "((key = $1 AND key2 != '') OR (key3 LIKE $2 AND key4 > $3))", Params: []any{"val", "%val3%", 123.0}}, &output.SQLOutput{})
+var invalidOutputElastic0, _ = contract.NewInputOutputType(map[string]any{"match": map[string]any{"key": "val"}}, &output.ElasticOutput{})
+
+var testOutputElasticFromSQL0, _ = contract.NewInputOutputType(map[string]any{"bool": map[string]any{"should": []map[string]any{{"term": map[string]any{"key.lowersortable": "val"}}, {"bool": map[string]any{"must_not": []map[string]any{{"term": map[string]any{"key2.lowersortable": "val2"}}}}}}, "minimum_should_match": 1}}, &output.ElasticOutput{})
+
+var testOutputElasticFromMongo0, _ = contract.NewInputOutputType(map[string]any{"bool": map[string]any{"should": []map[string]any{{"bool": map[string]any{"must": []map[string]any{{"term": map[string]any{"key.lowersortable": "val"}}}, "must_not": []map[string]any{{"term": map[string]any{"key2.lowersortable": ""}}}}}, {"bool": map[string]any{"must": []map[string]any{{"wildcard": map[string]any{"key3.lowersortable": "*val3*"}}, {"range": map[string]any{"key4": map[string]any{"gt": 123.0}}}}}}}, "minimum_should_match": 1}}, &output.ElasticOutput{})
+
+var testOutputSQL0, _ = contract.NewInputOutputType(output.SQLTuple{Query: `"key" = $1`, Params: []any{"val"}}, &output.SQLOutput{})
+var testOutputSQL1, _ = contract.NewInputOutputType(output.SQLTuple{Query: `("key" = $1 OR "key2" != $2)`, Params: []any{"val", "val2"}}, &output.SQLOutput{})
+var testOutputSQL2, _ = contract.NewInputOutputType(output.SQLTuple{Query: `"key" IS NOT NULL`, Params: nil}, &output.SQLOutput{})
+var testOutputSQL3, _ = contract.NewInputOutputType(output.SQLTuple{Query: `"key" >= $1`, Params: []any{123.0}}, &output.SQLOutput{})
+var testOutputSQLFromMongo0, _ = contract.NewInputOutputType(output.SQLTuple{Query: `(("key" = $1 AND "key2" != $2) OR ("key3" LIKE $3 AND "key4" > $4))`, Params: []any{"val", "", "%val3%", 123.0}}, &output.SQLOutput{})
+var testOutputSQL4, _ = contract.NewInputOutputType(output.SQLTuple{Query: `(("key" = $1 AND "key2" != '') OR ("key3" LIKE $2 AND "key4" > $3))`, Params: []any{"val", "%val3%", 123.0}}, &output.SQLOutput{})
 
 func TestBasic(t *testing.T) {
 	it := input.JsonInputTransformer{}
@@ -490,3 +499,740 @@ func TestFilterTransformer_CustomValidation(t *testing.T) {
 		})
 	}
 }
+
+func formDataTwinOfJson(operator contract.FilterOperator) (map[string][]string, []byte) {
+	formData := map[string][]string{
+		"filter[logic]":                                  {"or"},
+		"filter[conditions][0][field]":                   {"key"},
+		"filter[conditions][0][operator]":                {string(operator)},
+		"filter[conditions][1][logic]":                   {"and"},
+		"filter[conditions][1][conditions][0][field]":    {"key2"},
+		"filter[conditions][1][conditions][0][operator]": {string(operator)},
+	}
+	value := `"val"`
+	switch operator {
+	case contract.FilterOperatorIsNil, contract.FilterOperatorIsNotNil, contract.FilterOperatorIsEmpty, contract.FilterOperatorIsNotEmpty:
+		value = `null`
+	case contract.FilterOperatorIn, contract.FilterOperatorNotIn:
+		value = `["val", "val2"]`
+		formData["filter[conditions][0][value][]"] = []string{"val", "val2"}
+		formData["filter[conditions][1][conditions][0][value][]"] = []string{"val", "val2"}
+	default:
+		formData["filter[conditions][0][value]"] = []string{"val"}
+		formData["filter[conditions][1][conditions][0][value]"] = []string{"val"}
+	}
+	jsonData := []byte(fmt.Sprintf(
+		`{"logic": "or", "conditions": [{"field": "key", "operator": "%[1]s", "value": %[2]s}, {"logic": "and", "conditions": [{"field": "key2", "operator": "%[1]s", "value": %[2]s}]}]}`,
+		operator,
+		value,
+	))
+	return formData, jsonData
+}
+
+func TestFilterTransformer_Schema(t *testing.T) {
+	schema := contract.Schema{
+		"age":    {Type: contract.FieldTypeInt},
+		"status": {Type: contract.FieldTypeEnum, Values: []string{"new", "paid"}},
+	}
+	ft := NewJsonToSQLFilterTransformer().WithValidationFunc(schema.Validate)
+
+	valid, _ := contract.NewInputOutputType([]byte(`{"logic": "and", "conditions": [{"field": "age", "operator": "gte", "value": 18}, {"field": "status", "operator": "in", "value": "new,paid"}]}`), &input.JsonInput{})
+	if _, err := ft.Transform(valid); err != nil {
+		t.Errorf("Transform() error = %v", err)
+	}
+
+	invalid, _ := contract.NewInputOutputType([]byte(`{"logic": "and", "conditions": [{"field": "age", "operator": "gte", "value": "adult"}, {"logic": "or", "conditions": [{"field": "status", "operator": "begins", "value": "n"}, {"field": "email", "operator": "eq", "value": "x"}]}]}`), &input.JsonInput{})
+	_, err := ft.Transform(invalid)
+	wantErr := contract.NewError(contract.InvalidFiltersStructure, []contract.ValidationError{
+		{Path: "root.conditions.0.value", Error: contract.ValidationErrorInvalidValue, Field: "value", Payload: contract.SchemaValidationPayload{Field: "age", Expected: "int", Actual: "adult"}},
+		{Path: "root.conditions.0.conditions.0.operator", Error: contract.ValidationErrorUnsupportedOperator, Field: "operator", Payload: contract.SchemaValidationPayload{Field: "status", Expected: "eq, neq, in, not-in", Actual: "begins"}},
+		{Path: "root.conditions.0.conditions.1.field", Error: contract.ValidationErrorUnknownField, Field: "field", Payload: "email"},
+	})
+	if !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Transform() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFilterTransformer_WithSchema(t *testing.T) {
+	schema := contract.Schema{
+		"age":     {Type: contract.FieldTypeInt},
+		"created": {Type: contract.FieldTypeDate},
+		"id":      {Type: contract.FieldTypeUUID},
+	}
+	jsonInput, _ := contract.NewInputOutputType([]byte(`{"logic": "and", "conditions": [{"field": "age", "operator": "in", "value": [18, 21]}, {"field": "created", "operator": "gte", "value": "2024-01-01"}, {"field": "id", "operator": "eq", "value": "6F9619FF-8B86-D011-B42D-00C04FC964FF"}]}`), &input.JsonInput{})
+
+	toSQL := NewJsonToSQLFilterTransformer().WithSchema(schema).WithFieldMapping(contract.FieldMapping{
+		"age":     {"users.age"},
+		"created": {"users.created_at"},
+		"id":      {"users.id"},
+	})
+	gotSQL, err := toSQL.Transform(jsonInput)
+	if err != nil {
+		t.Fatalf("Transform() to SQL error = %v", err)
+	}
+	wantSQL, _ := contract.NewInputOutputType(output.SQLTuple{
+		Query:  `("users"."age" IN ($1, $2) AND "users"."created_at" >= $3 AND "users"."id" = $4)`,
+		Params: []any{int64(18), int64(21), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "6f9619ff-8b86-d011-b42d-00c04fc964ff"},
+	}, &output.SQLOutput{})
+	if !reflect.DeepEqual(gotSQL, wantSQL) {
+		t.Errorf("Transform() to SQL got = %v, want %v", gotSQL, wantSQL)
+	}
+
+	invalid, _ := contract.NewInputOutputType([]byte(`{"logic": "and", "conditions": [{"field": "age", "operator": "in", "value": [18, 21.5]}]}`), &input.JsonInput{})
+	_, err = NewJsonToElasticFilterTransformer().WithSchema(schema).Transform(invalid)
+	wantErr := contract.NewError(contract.InvalidFiltersStructure, []contract.ValidationError{
+		{Path: "root.conditions.0.value.1", Error: contract.ValidationErrorInvalidValue, Field: "value", Payload: contract.SchemaValidationPayload{Field: "age", Expected: "int", Actual: 21.5}},
+	})
+	if !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Transform() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFilterTransformer_FieldMapping(t *testing.T) {
+	jsonInput, _ := contract.NewInputOutputType([]byte(`{"logic": "and", "conditions": [{"field": "createdAt", "operator": "gte", "value": "2024-01-01"}, {"field": "name", "operator": "begins", "value": "Jo"}]}`), &input.JsonInput{})
+
+	var validatedFields []string
+	toSQL := NewJsonToSQLFilterTransformer().WithFieldMapping(contract.FieldMapping{
+		"createdAt": {"orders.created_at"},
+		"name":      {"customers.first_name", "customers.last_name"},
+	}).WithValidationFunc(func(filterCondition contract.FilterCondition, path string, validationErrors *[]contract.ValidationError) {
+		validatedFields = append(validatedFields, filterCondition.Field)
+	})
+	gotSQL, err := toSQL.Transform(jsonInput)
+	if err != nil {
+		t.Fatalf("Transform() to SQL error = %v", err)
+	}
+	wantSQL, _ := contract.NewInputOutputType(output.SQLTuple{
+		Query:  `(("customers"."first_name" LIKE $1 OR "customers"."last_name" LIKE $2) AND "orders"."created_at" >= $3)`,
+		Params: []any{"Jo%", "Jo%", "2024-01-01"},
+	}, &output.SQLOutput{})
+	if !reflect.DeepEqual(gotSQL, wantSQL) {
+		t.Errorf("Transform() to SQL got = %v, want %v", gotSQL, wantSQL)
+	}
+	if wantFields := []string{"createdAt", "name"}; !reflect.DeepEqual(validatedFields, wantFields) {
+		t.Errorf("validation func got fields = %v, want %v", validatedFields, wantFields)
+	}
+
+	toElastic := NewJsonToElasticFilterTransformer().WithFieldMapping(contract.FieldMapping{
+		"createdAt": {"meta.created"},
+		"name":      {"customer.name"},
+	})
+	gotElastic, err := toElastic.Transform(jsonInput)
+	if err != nil {
+		t.Fatalf("Transform() to Elastic error = %v", err)
+	}
+	wantElastic, _ := contract.NewInputOutputType(map[string]any{"bool": map[string]any{"must": []map[string]any{
+		{"range": map[string]any{"meta.created": map[string]any{"gte": "2024-01-01"}}},
+		{"prefix": map[string]any{"customer.name.lowersortable": "Jo"}},
+	}}}, &output.ElasticOutput{})
+	if !reflect.DeepEqual(gotElastic, wantElastic) {
+		t.Errorf("Transform() to Elastic got = %v, want %v", gotElastic, wantElastic)
+	}
+
+	unmapped := NewJsonToElasticFilterTransformer().WithFieldMapping(contract.FieldMapping{"createdAt": {"meta.created"}})
+	_, err = unmapped.Transform(jsonInput)
+	wantErr := contract.NewError(contract.InvalidFiltersStructure, []contract.ValidationError{
+		{Path: "root.conditions.1.field", Error: contract.ValidationErrorUnmappedField, Field: "field", Payload: "name"},
+	})
+	if !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Transform() with unmapped field error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestFilterTransformer_TransformFormDataMatchesJson(t *testing.T) {
+	formDataToElastic := NewFormDataToElasticFilterTransformer()
+	formDataToSQL := NewFormDataToSQLFilterTransformer()
+	jsonToElastic := NewJsonToElasticFilterTransformer()
+	jsonToSQL := NewJsonToSQLFilterTransformer()
+	for _, operator := range contract.SupportedOperators() {
+		t.Run(string(operator), func(t *testing.T) {
+			formData, jsonData := formDataTwinOfJson(operator)
+			formDataInput, _ := contract.NewInputOutputType(formData, &input.FormDataInput{})
+			jsonInput, _ := contract.NewInputOutputType(jsonData, &input.JsonInput{})
+
+			formDataElastic, err := formDataToElastic.Transform(formDataInput)
+			if err != nil {
+				t.Fatalf("Transform() form data to Elastic error = %v", err)
+			}
+			jsonElastic, err := jsonToElastic.Transform(jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON to Elastic error = %v", err)
+			}
+			formDataElasticJson, _ := formDataElastic.GetDataJson()
+			jsonElasticJson, _ := jsonElastic.GetDataJson()
+			if string(formDataElasticJson) != string(jsonElasticJson) {
+				t.Errorf("Elastic output differs: form data = %s, JSON = %s", formDataElasticJson, jsonElasticJson)
+			}
+
+			formDataSQL, err := formDataToSQL.Transform(formDataInput)
+			if err != nil {
+				t.Fatalf("Transform() form data to SQL error = %v", err)
+			}
+			jsonSQL, err := jsonToSQL.Transform(jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON to SQL error = %v", err)
+			}
+			formDataSQLJson, _ := formDataSQL.GetDataJson()
+			jsonSQLJson, _ := jsonSQL.GetDataJson()
+			if string(formDataSQLJson) != string(jsonSQLJson) {
+				t.Errorf("SQL output differs: form data = %s, JSON = %s", formDataSQLJson, jsonSQLJson)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformFormData(t *testing.T) {
+	ft := NewFormDataToSQLFilterTransformer()
+	tests := []struct {
+		name    string
+		input   map[string][]string
+		want    *output.SQLOutput
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			input:   map[string][]string{},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with data",
+			input: map[string][]string{
+				"filter[logic]":                   {"and"},
+				"filter[conditions][0][field]":    {"key"},
+				"filter[conditions][0][operator]": {"eq"},
+				"filter[conditions][0][value]":    {"val"},
+			},
+			want:    testOutputSQL0,
+			wantErr: false,
+		},
+		{
+			name: "invalid input - malformed key",
+			input: map[string][]string{
+				"filter[conditions][0]field": {"key"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid input - unsupported operator",
+			input: map[string][]string{
+				"filter[conditions][0][field]":    {"key"},
+				"filter[conditions][0][operator]": {"ss"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formDataInput, _ := contract.NewInputOutputType(tt.input, &input.FormDataInput{})
+			got, err := ft.Transform(formDataInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformExpressionRoundTrip(t *testing.T) {
+	jsonToExpression := NewJsonToExpressionFilterTransformer()
+	inputTransformer := input.ExpressionInputTransformer{}
+	outputTransformer := output.ExpressionOutputTransformer{}
+	jsonInputs := map[string]*input.JsonInput{
+		"with data":         testInputJson0,
+		"with nested data":  testInputJson1,
+		"with null value":   testInputJson2,
+		"with number value": testInputJson3,
+		"with complex data": testInputJson4,
+		"with deep nesting": testInputJson5,
+		"with in list":      testInputJson7,
+	}
+	for _, operator := range contract.SupportedOperators() {
+		_, jsonData := formDataTwinOfJson(operator)
+		jsonInputs[string(operator)], _ = contract.NewInputOutputType(jsonData, &input.JsonInput{})
+	}
+	for name, jsonInput := range jsonInputs {
+		t.Run(name, func(t *testing.T) {
+			expressionOutput, err := jsonToExpression.Transform(jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON to expression error = %v", err)
+			}
+			expression, _ := expressionOutput.GetDataString()
+			expressionInput, _ := contract.NewInputOutputType(expression, &input.ExpressionInput{})
+			filters, err := inputTransformer.Transform(expressionInput)
+			if err != nil {
+				t.Fatalf("Transform() of %q error = %v", expression, err)
+			}
+			reprintedOutput, err := outputTransformer.Transform(filters)
+			if err != nil {
+				t.Fatalf("Transform() expression output error = %v", err)
+			}
+			reprinted, _ := reprintedOutput.GetDataString()
+			if reprinted != expression {
+				t.Errorf("round trip differs: got = %s, want %s", reprinted, expression)
+			}
+			reprintedInput, _ := contract.NewInputOutputType(reprinted, &input.ExpressionInput{})
+			reparsed, err := inputTransformer.Transform(reprintedInput)
+			if err != nil {
+				t.Fatalf("Transform() of %q error = %v", reprinted, err)
+			}
+			if !reflect.DeepEqual(reparsed, filters) {
+				t.Errorf("round trip differs: got = %v, want %v", reparsed, filters)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformExpressionRoundTripNestedSingleChildGroups(t *testing.T) {
+	jsonInputs := []string{
+		`{"logic": "and", "conditions": [{"logic": "and", "conditions": [{"logic": "or", "conditions": [{"field": "a", "operator": "eq", "value": "1"}, {"field": "b", "operator": "eq", "value": "2"}]}]}, {"field": "c", "operator": "eq", "value": "3"}]}`,
+		`{"logic": "or", "conditions": [{"logic": "or", "conditions": [{"logic": "and", "conditions": [{"logic": "and", "conditions": [{"field": "a", "operator": "eq", "value": "1"}, {"field": "b", "operator": "eq", "value": "2"}]}]}]}, {"field": "c", "operator": "eq", "value": "3"}]}`,
+		`{"logic": "and", "conditions": [{"logic": "or", "conditions": [{"logic": "and", "conditions": [{"field": "a", "operator": "eq", "value": "1"}]}, {"field": "b", "operator": "eq", "value": "2"}]}, {"field": "c", "operator": "eq", "value": "3"}]}`,
+	}
+	jsonTransformer := input.JsonInputTransformer{}
+	expressionInputTransformer := input.ExpressionInputTransformer{}
+	expressionOutputTransformer := output.ExpressionOutputTransformer{}
+	predicateOutputTransformer := output.PredicateOutputTransformer{}
+	for _, jsonData := range jsonInputs {
+		t.Run(jsonData, func(t *testing.T) {
+			jsonInput, _ := contract.NewInputOutputType([]byte(jsonData), &input.JsonInput{})
+			filters, err := jsonTransformer.Transform(jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON error = %v", err)
+			}
+			expressionOutput, err := expressionOutputTransformer.Transform(filters)
+			if err != nil {
+				t.Fatalf("Transform() expression output error = %v", err)
+			}
+			expression, _ := expressionOutput.GetDataString()
+			expressionInput, _ := contract.NewInputOutputType(expression, &input.ExpressionInput{})
+			reparsed, err := expressionInputTransformer.Transform(expressionInput)
+			if err != nil {
+				t.Fatalf("Transform() of %q error = %v", expression, err)
+			}
+			// the groups may be flattened, so the filters are compared by evaluating them
+			want, _ := predicateOutputTransformer.Transform(filters)
+			got, _ := predicateOutputTransformer.Transform(reparsed)
+			wantPredicate, _ := want.GetData()
+			gotPredicate, _ := got.GetData()
+			for _, a := range []string{"1", "0"} {
+				for _, b := range []string{"2", "0"} {
+					for _, c := range []string{"3", "0"} {
+						record := map[string]any{"a": a, "b": b, "c": c}
+						wantMatch, _ := wantPredicate(record)
+						gotMatch, _ := gotPredicate(record)
+						if gotMatch != wantMatch {
+							t.Errorf("%q read back differs for %v: got = %v, want %v", expression, record, gotMatch, wantMatch)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformElasticRoundTrip(t *testing.T) {
+	jsonTransformer := input.JsonInputTransformer{}
+	elasticInputTransformer := input.ElasticInputTransformer{}
+	elasticOutputTransformer := output.ElasticOutputTransformer{}
+	// the Elastic output uses the same query for these operators, so they are read back as their closest equivalent
+	readBackOperators := map[contract.FilterOperator]contract.FilterOperator{
+		contract.FilterOperatorIsEmpty:    contract.FilterOperatorIsNil,
+		contract.FilterOperatorIsNotEmpty: contract.FilterOperatorIsNotNil,
+	}
+	for _, operator := range contract.SupportedOperators() {
+		t.Run(string(operator), func(t *testing.T) {
+			_, jsonData := formDataTwinOfJson(operator)
+			jsonInput, _ := contract.NewInputOutputType(jsonData, &input.JsonInput{})
+			want, err := jsonTransformer.Transform(jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON error = %v", err)
+			}
+			elasticOutput, err := elasticOutputTransformer.Transform(want)
+			if err != nil {
+				t.Fatalf("Transform() Elastic output error = %v", err)
+			}
+			elasticData, _ := elasticOutput.GetData()
+			elasticInput, _ := contract.NewInputOutputType(elasticData, &input.ElasticInput{})
+			got, err := elasticInputTransformer.Transform(elasticInput)
+			if err != nil {
+				t.Fatalf("Transform() Elastic input error = %v", err)
+			}
+
+			// the nested group contains a single condition, so it is lifted into the parent
+			want.Conditions.Conditions = append(want.Conditions.Filters[0].Conditions.Conditions, want.Conditions.Conditions...)
+			want.Conditions.Filters = nil
+			for index, condition := range want.Conditions.Conditions {
+				if readBackOperator, isReadBack := readBackOperators[condition.Operator]; isReadBack {
+					want.Conditions.Conditions[index].Operator = readBackOperator
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip differs: got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformElasticToSQL(t *testing.T) {
+	ft := NewElasticToSQLFilterTransformer()
+	tests := []struct {
+		name    string
+		input   *output.ElasticOutput
+		want    *output.SQLOutput
+		wantErr bool
+	}{
+		{
+			name:    "with data",
+			input:   testOutputElastic0,
+			want:    testOutputSQL0,
+			wantErr: false,
+		},
+		{
+			name:    "with nested data",
+			input:   testOutputElastic1,
+			want:    testOutputSQL1,
+			wantErr: false,
+		},
+		{
+			name:    "with null value",
+			input:   testOutputElastic2,
+			want:    testOutputSQL2,
+			wantErr: false,
+		},
+		{
+			name:    "with number value",
+			input:   testOutputElastic3,
+			want:    testOutputSQL3,
+			wantErr: false,
+		},
+		{
+			name:    "invalid input - unsupported clause",
+			input:   invalidOutputElastic0,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elasticData, _ := tt.input.GetData()
+			elasticInput, _ := contract.NewInputOutputType(elasticData, &input.ElasticInput{})
+			got, err := ft.Transform(elasticInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformSQLToElastic(t *testing.T) {
+	ft := NewSQLToElasticFilterTransformer()
+	tests := []struct {
+		name    string
+		input   string
+		want    *output.ElasticOutput
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			input:   "",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with data",
+			input:   "key = 'val'",
+			want:    testOutputElastic0,
+			wantErr: false,
+		},
+		{
+			name:    "with nested data",
+			input:   "key = 'val' OR key2 != 'val2'",
+			want:    testOutputElasticFromSQL0,
+			wantErr: false,
+		},
+		{
+			name:    "with number value",
+			input:   "key >= 123",
+			want:    testOutputElastic3,
+			wantErr: false,
+		},
+		{
+			name:    "invalid input - unsupported function",
+			input:   "lower(key) = 'val'",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlInput, _ := contract.NewInputOutputType(tt.input, &input.SQLInput{})
+			got, err := ft.Transform(sqlInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformMongo(t *testing.T) {
+	mongoToElastic := NewMongoToElasticFilterTransformer()
+	mongoToSQL := NewMongoToSQLFilterTransformer()
+	tests := []struct {
+		name        string
+		input       string
+		wantElastic *output.ElasticOutput
+		wantSQL     *output.SQLOutput
+		wantErr     bool
+	}{
+		{
+			name:        "empty",
+			input:       `{}`,
+			wantElastic: nil,
+			wantSQL:     nil,
+			wantErr:     true,
+		},
+		{
+			name:        "with data",
+			input:       `{"key": "val"}`,
+			wantElastic: testOutputElastic0,
+			wantSQL:     testOutputSQL0,
+			wantErr:     false,
+		},
+		{
+			name:        "with number value",
+			input:       `{"key": {"$gte": 123}}`,
+			wantElastic: testOutputElastic3,
+			wantSQL:     testOutputSQL3,
+			wantErr:     false,
+		},
+		{
+			name:        "with complex data",
+			input:       `{"$or": [{"key": "val", "key2": {"$ne": ""}}, {"key3": {"$regex": "val3"}, "key4": {"$gt": 123}}]}`,
+			wantElastic: testOutputElasticFromMongo0,
+			wantSQL:     testOutputSQLFromMongo0,
+			wantErr:     false,
+		},
+		{
+			name:        "invalid input - unsupported operator",
+			input:       `{"key": {"$size": 1}}`,
+			wantElastic: nil,
+			wantSQL:     nil,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mongoInput, _ := input.NewMongoInputFromJson([]byte(tt.input))
+			gotElastic, err := mongoToElastic.Transform(mongoInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transform() to Elastic error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotElastic, tt.wantElastic) {
+				t.Errorf("Transform() to Elastic got = %v, want %v", gotElastic, tt.wantElastic)
+			}
+			gotSQL, err := mongoToSQL.Transform(mongoInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transform() to SQL error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotSQL, tt.wantSQL) {
+				t.Errorf("Transform() to SQL got = %v, want %v", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformMongoRoundTrip(t *testing.T) {
+	jsonToMongo := NewJsonToMongoFilterTransformer()
+	jsonTransformer := input.JsonInputTransformer{}
+	mongoInputTransformer := input.MongoInputTransformer{}
+	// the Mongo output uses a regex for match-phrase, so it is read back as contains
+	readBackOperators := map[contract.FilterOperator]contract.FilterOperator{
+		contract.FilterOperatorMatchPhrase: contract.FilterOperatorContains,
+	}
+	// these are written as a combination of operators, which the Mongo input reads back as such
+	skippedOperators := []contract.FilterOperator{
+		contract.FilterOperatorGreaterThanOrEqualOrNil,
+		contract.FilterOperatorLowerThanOrEqualOrNil,
+		contract.FilterOperatorIsEmpty,
+		contract.FilterOperatorIsNotEmpty,
+	}
+	for _, operator := range contract.SupportedOperators() {
+		if slices.Contains(skippedOperators, operator) {
+			continue
+		}
+		t.Run(string(operator), func(t *testing.T) {
+			_, jsonData := formDataTwinOfJson(operator)
+			jsonInput, _ := contract.NewInputOutputType(jsonData, &input.JsonInput{})
+			want, err := jsonTransformer.Transform(jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON error = %v", err)
+			}
+			mongoOutput, err := jsonToMongo.Transform(jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() Mongo output error = %v", err)
+			}
+			mongoData, _ := mongoOutput.GetDataJson()
+			mongoInput, _ := input.NewMongoInputFromJson(mongoData)
+			got, err := mongoInputTransformer.Transform(mongoInput)
+			if err != nil {
+				t.Fatalf("Transform() Mongo input of %s error = %v", mongoData, err)
+			}
+
+			// the nested group contains a single condition, so it is lifted into the parent
+			want.Conditions.Conditions = append(want.Conditions.Filters[0].Conditions.Conditions, want.Conditions.Conditions...)
+			want.Conditions.Filters = nil
+			for index, condition := range want.Conditions.Conditions {
+				if readBackOperator, isReadBack := readBackOperators[condition.Operator]; isReadBack {
+					want.Conditions.Conditions[index].Operator = readBackOperator
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip of %s differs: got = %v, want %v", mongoData, got, want)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformJsonToPredicate(t *testing.T) {
+	ft := NewJsonToPredicateFilterTransformer()
+	tests := []struct {
+		name   string
+		input  *input.JsonInput
+		record map[string]any
+		want   bool
+	}{
+		{
+			name:   "with data",
+			input:  testInputJson0,
+			record: map[string]any{"key": "val"},
+			want:   true,
+		},
+		{
+			name:   "with nested data",
+			input:  testInputJson1,
+			record: map[string]any{"key": "other", "key2": "val2"},
+			want:   false,
+		},
+		{
+			name:   "with complex data",
+			input:  testInputJson4,
+			record: map[string]any{"key": "other", "key3": "a val3 b", "key4": 124},
+			want:   true,
+		},
+		{
+			name:   "with complex data and null",
+			input:  testInputJson4,
+			record: map[string]any{"key": "val", "key2": nil, "key3": "a val3 b"},
+			want:   false,
+		},
+		{
+			name:   "with a list",
+			input:  testInputJson7,
+			record: map[string]any{"key": "val2"},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicateOutput, err := ft.Transform(tt.input)
+			if err != nil {
+				t.Fatalf("Transform() error = %v", err)
+			}
+			predicate, _ := predicateOutput.GetData()
+			got, predicateErr := predicate(tt.record)
+			if predicateErr != nil {
+				t.Fatalf("predicate() error = %v", predicateErr)
+			}
+			if got != tt.want {
+				t.Errorf("predicate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterTransformer_TransformYAMLMatchesJson(t *testing.T) {
+	yamlToElastic := NewYAMLToElasticFilterTransformer()
+	yamlToSQL := NewYAMLToSQLFilterTransformer()
+	jsonToElastic := NewJsonToElasticFilterTransformer()
+	jsonToSQL := NewJsonToSQLFilterTransformer()
+	blockStyle := []byte(`logic: or
+conditions:
+  - logic: and
+    conditions:
+      - {field: key, operator: eq, value: val}
+      - {field: key2, operator: not-empty}
+  - logic: and
+    conditions:
+      - field: key3
+        operator: contains
+        value: val3
+      - field: key4
+        operator: gt
+        value: 123
+`)
+	tests := []struct {
+		name      string
+		yamlInput []byte
+		jsonInput *input.JsonInput
+	}{
+		{name: "block style", yamlInput: blockStyle, jsonInput: testInputJson4},
+	}
+	// JSON documents are valid YAML
+	for name, jsonInput := range map[string]*input.JsonInput{"json0": testInputJson0, "json1": testInputJson1, "json2": testInputJson2, "json3": testInputJson3, "json5": testInputJson5, "json9": testInputJson9} {
+		rawData, _ := jsonInput.GetData()
+		tests = append(tests, struct {
+			name      string
+			yamlInput []byte
+			jsonInput *input.JsonInput
+		}{name: name, yamlInput: rawData, jsonInput: jsonInput})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlInput, _ := contract.NewInputOutputType(tt.yamlInput, &input.YAMLInput{})
+
+			yamlElastic, err := yamlToElastic.Transform(yamlInput)
+			if err != nil {
+				t.Fatalf("Transform() YAML to Elastic error = %v", err)
+			}
+			jsonElastic, err := jsonToElastic.Transform(tt.jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON to Elastic error = %v", err)
+			}
+			yamlElasticJson, _ := yamlElastic.GetDataJson()
+			jsonElasticJson, _ := jsonElastic.GetDataJson()
+			if string(yamlElasticJson) != string(jsonElasticJson) {
+				t.Errorf("Elastic output differs: YAML = %s, JSON = %s", yamlElasticJson, jsonElasticJson)
+			}
+
+			yamlSQL, err := yamlToSQL.Transform(yamlInput)
+			if err != nil {
+				t.Fatalf("Transform() YAML to SQL error = %v", err)
+			}
+			jsonSQL, err := jsonToSQL.Transform(tt.jsonInput)
+			if err != nil {
+				t.Fatalf("Transform() JSON to SQL error = %v", err)
+			}
+			yamlSQLJson, _ := yamlSQL.GetDataJson()
+			jsonSQLJson, _ := jsonSQL.GetDataJson()
+			if string(yamlSQLJson) != string(jsonSQLJson) {
+				t.Errorf("SQL output differs: YAML = %s, JSON = %s", yamlSQLJson, jsonSQLJson)
+			}
+		})
+	}
+}
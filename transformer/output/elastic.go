@@ -3,6 +3,9 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
 )
@@ -30,196 +33,252 @@ func (o *ElasticOutput) GetDataString() (string, error) {
 	return string(rawData), nil
 }
 
-func getElasticFieldVariantByValueType(condition contract.FilterCondition) string {
-	field := fmt.Sprintf("%s.lowersortable", condition.Field)
-	value := condition.Value
-	slice, isSlice := value.([]interface{})
-	if isSlice && len(slice) > 0 {
-		value = slice[0]
-	}
-	_, isInt := value.(int)
-	_, isFloat := value.(float64)
-	_, isBool := value.(bool)
-	if isInt || isFloat || isBool {
-		field = condition.Field
+type ElasticFieldType string
+
+const (
+	ElasticFieldTypeText    ElasticFieldType = "text"
+	ElasticFieldTypeKeyword ElasticFieldType = "keyword"
+	ElasticFieldTypeNumeric ElasticFieldType = "numeric"
+	ElasticFieldTypeDate    ElasticFieldType = "date"
+	ElasticFieldTypeBoolean ElasticFieldType = "boolean"
+
+	// ElasticDefaultKeywordSubField is the keyword sub-field of strings when the field is missing in the mapping
+	ElasticDefaultKeywordSubField = "lowersortable"
+)
+
+// ElasticFieldMapping describes how a field is indexed
+type ElasticFieldMapping struct {
+	Type ElasticFieldType
+	// KeywordSubField is the keyword sub-field of a text field (e.g. `keyword` for `name.keyword`), used by the term-level
+	// queries (term, terms, prefix, wildcard and range); text fields without it are queried using full-text queries
+	KeywordSubField string
+	// NestedPaths are the paths of the `nested` objects containing the field (outermost first), the queries on the field
+	// are wrapped in a `nested` query for each of them
+	NestedPaths []string
+}
+
+// elasticPatternOperators are only supported by text and keyword fields
+var elasticPatternOperators = []contract.FilterOperator{
+	contract.FilterOperatorBegins,
+	contract.FilterOperatorContains,
+	contract.FilterOperatorNotContains,
+	contract.FilterOperatorEnds,
+}
+
+// elasticWildcardOperators need a keyword (sub-)field, a wildcard on an analyzed text field would only match single terms
+var elasticWildcardOperators = []contract.FilterOperator{
+	contract.FilterOperatorContains,
+	contract.FilterOperatorNotContains,
+	contract.FilterOperatorEnds,
+}
+
+var elasticRangeOperators = []contract.FilterOperator{
+	contract.FilterOperatorGreaterThan,
+	contract.FilterOperatorGreaterThanOrEqual,
+	contract.FilterOperatorGreaterThanOrEqualOrNil,
+	contract.FilterOperatorLowerThan,
+	contract.FilterOperatorLowerThanOrEqual,
+	contract.FilterOperatorLowerThanOrEqualOrNil,
+}
+
+// guessElasticFieldMapping is used for fields missing in the mapping (without a default): strings use the
+// `lowersortable` keyword sub-field, numbers, booleans and dates (e.g. coerced by a schema) and all ranges use the field itself
+func guessElasticFieldMapping(condition contract.FilterCondition) ElasticFieldMapping {
+	if slices.Contains(elasticPatternOperators, condition.Operator) {
+		return ElasticFieldMapping{Type: ElasticFieldTypeText, KeywordSubField: ElasticDefaultKeywordSubField}
+	}
+	if slices.Contains(elasticRangeOperators, condition.Operator) {
+		return ElasticFieldMapping{Type: ElasticFieldTypeKeyword}
+	}
+	value := reflect.ValueOf(condition.Value)
+	if (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Len() > 0 {
+		value = reflect.ValueOf(value.Index(0).Interface())
+	}
+	switch {
+	case value.CanInt() || value.CanUint() || value.CanFloat():
+		return ElasticFieldMapping{Type: ElasticFieldTypeNumeric}
+	case value.Kind() == reflect.Bool:
+		return ElasticFieldMapping{Type: ElasticFieldTypeBoolean}
+	case value.IsValid() && value.Type() == reflect.TypeOf(time.Time{}):
+		return ElasticFieldMapping{Type: ElasticFieldTypeDate}
+	}
+	return ElasticFieldMapping{Type: ElasticFieldTypeText, KeywordSubField: ElasticDefaultKeywordSubField}
+}
+
+// termField is the target of the term-level queries
+func (m ElasticFieldMapping) termField(field string) string {
+	if m.Type == ElasticFieldTypeText && m.KeywordSubField != "" {
+		return fmt.Sprintf("%s.%s", field, m.KeywordSubField)
 	}
 	return field
 }
 
-var conditionResolversElastic = map[contract.FilterOperator]func(contract.FilterCondition) map[string]any{
-	contract.FilterOperatorEqual: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"term": map[string]any{
-				getElasticFieldVariantByValueType(condition): condition.Value,
-			},
-		}
-	},
-	contract.FilterOperatorNotEqual: func(condition contract.FilterCondition) map[string]any {
+// isFullText is true for text fields without a keyword sub-field
+func (m ElasticFieldMapping) isFullText() bool {
+	return m.Type == ElasticFieldTypeText && m.KeywordSubField == ""
+}
+
+// elasticContext carries the options of the transformer through the transformation
+type elasticContext struct {
+	fields       map[string]ElasticFieldMapping
+	defaultField *ElasticFieldMapping
+}
+
+func (c *elasticContext) fieldMapping(condition contract.FilterCondition) ElasticFieldMapping {
+	if mapping, isMapped := c.fields[condition.Field]; isMapped {
+		return mapping
+	}
+	if c.defaultField != nil {
+		return *c.defaultField
+	}
+	return guessElasticFieldMapping(condition)
+}
+
+func elasticTerm(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+	if mapping.isFullText() {
 		return map[string]any{
-			"term": map[string]any{
-				getElasticFieldVariantByValueType(condition): condition.Value,
+			"match_phrase": map[string]any{
+				condition.Field: fmt.Sprint(condition.Value),
 			},
 		}
-	},
-	contract.FilterOperatorGreaterThan: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"range": map[string]any{
-				condition.Field: map[string]any{
-					"gt": condition.Value,
+	}
+	return map[string]any{
+		"term": map[string]any{
+			mapping.termField(condition.Field): condition.Value,
+		},
+	}
+}
+
+func elasticTerms(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+	if mapping.isFullText() {
+		var phrases []map[string]any
+		for _, value := range condition.ValueList() {
+			phrases = append(phrases, map[string]any{
+				"match_phrase": map[string]any{
+					condition.Field: fmt.Sprint(value),
 				},
-			},
+			})
 		}
-	},
-	contract.FilterOperatorGreaterThanOrEqual: func(condition contract.FilterCondition) map[string]any {
 		return map[string]any{
-			"range": map[string]any{
-				condition.Field: map[string]any{
-					"gte": condition.Value,
-				},
+			"bool": map[string]any{
+				"should":               phrases,
+				"minimum_should_match": 1,
 			},
 		}
-	},
-	contract.FilterOperatorGreaterThanOrEqualOrNil: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"bool": map[string]any{
-				"should": []map[string]any{
-					{
-						"range": map[string]any{
-							condition.Field: map[string]any{
-								"gte": condition.Value,
-							},
-						},
-					},
-					{
-						"bool": map[string]any{
-							"must_not": []map[string]any{
-								{
-									"exists": map[string]any{
-										"field": condition.Field,
-									},
+	}
+	return map[string]any{
+		"terms": map[string]any{
+			mapping.termField(condition.Field): condition.Value,
+		},
+	}
+}
+
+func elasticRange(condition contract.FilterCondition, mapping ElasticFieldMapping, key string) map[string]any {
+	return map[string]any{
+		"range": map[string]any{
+			mapping.termField(condition.Field): map[string]any{
+				key: condition.Value,
+			},
+		},
+	}
+}
+
+func elasticRangeOrNil(condition contract.FilterCondition, mapping ElasticFieldMapping, key string) map[string]any {
+	return map[string]any{
+		"bool": map[string]any{
+			"should": []map[string]any{
+				elasticRange(condition, mapping, key),
+				{
+					"bool": map[string]any{
+						"must_not": []map[string]any{
+							{
+								"exists": map[string]any{
+									"field": condition.Field,
 								},
 							},
 						},
 					},
 				},
 			},
-		}
+		},
+	}
+}
+
+func elasticWildcard(condition contract.FilterCondition, mapping ElasticFieldMapping, pattern string) map[string]any {
+	return map[string]any{
+		"wildcard": map[string]any{
+			mapping.termField(condition.Field): fmt.Sprintf(pattern, condition.Value),
+		},
+	}
+}
+
+func elasticExists(condition contract.FilterCondition) map[string]any {
+	return map[string]any{
+		"exists": map[string]any{
+			"field": condition.Field,
+		},
+	}
+}
+
+var conditionResolversElastic = map[contract.FilterOperator]func(contract.FilterCondition, ElasticFieldMapping) map[string]any{
+	contract.FilterOperatorEqual:    elasticTerm,
+	contract.FilterOperatorNotEqual: elasticTerm,
+	contract.FilterOperatorGreaterThan: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticRange(condition, mapping, "gt")
 	},
-	contract.FilterOperatorLowerThan: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"range": map[string]any{
-				condition.Field: map[string]any{
-					"lt": condition.Value,
-				},
-			},
-		}
+	contract.FilterOperatorGreaterThanOrEqual: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticRange(condition, mapping, "gte")
 	},
-	contract.FilterOperatorLowerThanOrEqual: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"range": map[string]any{
-				condition.Field: map[string]any{
-					"lte": condition.Value,
-				},
-			},
-		}
+	contract.FilterOperatorGreaterThanOrEqualOrNil: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticRangeOrNil(condition, mapping, "gte")
 	},
-	contract.FilterOperatorLowerThanOrEqualOrNil: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"bool": map[string]any{
-				"should": []map[string]any{
-					{
-						"range": map[string]any{
-							condition.Field: map[string]any{
-								"lte": condition.Value,
-							},
-						},
-					},
-					{
-						"bool": map[string]any{
-							"must_not": []map[string]any{
-								{
-									"exists": map[string]any{
-										"field": condition.Field,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		}
+	contract.FilterOperatorLowerThan: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticRange(condition, mapping, "lt")
 	},
-	contract.FilterOperatorBegins: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"prefix": map[string]any{
-				fmt.Sprintf("%s.lowersortable", condition.Field): condition.Value,
-			},
-		}
+	contract.FilterOperatorLowerThanOrEqual: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticRange(condition, mapping, "lte")
 	},
-	contract.FilterOperatorContains: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"wildcard": map[string]any{
-				fmt.Sprintf("%s.lowersortable", condition.Field): fmt.Sprintf("*%s*", condition.Value),
-			},
-		}
+	contract.FilterOperatorLowerThanOrEqualOrNil: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticRangeOrNil(condition, mapping, "lte")
 	},
-	contract.FilterOperatorNotContains: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"wildcard": map[string]any{
-				fmt.Sprintf("%s.lowersortable", condition.Field): fmt.Sprintf("*%s*", condition.Value),
-			},
+	contract.FilterOperatorBegins: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		if mapping.isFullText() {
+			return map[string]any{
+				"match_phrase_prefix": map[string]any{
+					condition.Field: fmt.Sprint(condition.Value),
+				},
+			}
 		}
-	},
-	contract.FilterOperatorEnds: func(condition contract.FilterCondition) map[string]any {
 		return map[string]any{
-			"wildcard": map[string]any{
-				fmt.Sprintf("%s.lowersortable", condition.Field): fmt.Sprintf("*%s", condition.Value),
+			"prefix": map[string]any{
+				mapping.termField(condition.Field): condition.Value,
 			},
 		}
 	},
-	contract.FilterOperatorIsNil: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"exists": map[string]any{
-				"field": condition.Field,
-			},
-		}
+	contract.FilterOperatorContains: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticWildcard(condition, mapping, "*%s*")
 	},
-	contract.FilterOperatorIsNotNil: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"exists": map[string]any{
-				"field": condition.Field,
-			},
-		}
+	contract.FilterOperatorNotContains: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticWildcard(condition, mapping, "*%s*")
 	},
-	contract.FilterOperatorIsEmpty: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"exists": map[string]any{
-				"field": condition.Field,
-			},
-		}
+	contract.FilterOperatorEnds: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticWildcard(condition, mapping, "*%s")
 	},
-	contract.FilterOperatorIsNotEmpty: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"exists": map[string]any{
-				"field": condition.Field,
-			},
-		}
+	contract.FilterOperatorIsNil: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticExists(condition)
 	},
-	contract.FilterOperatorIn: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"terms": map[string]any{
-				getElasticFieldVariantByValueType(condition): condition.Value,
-			},
-		}
+	contract.FilterOperatorIsNotNil: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticExists(condition)
 	},
-	contract.FilterOperatorNotIn: func(condition contract.FilterCondition) map[string]any {
-		return map[string]any{
-			"terms": map[string]any{
-				getElasticFieldVariantByValueType(condition): condition.Value,
-			},
-		}
+	contract.FilterOperatorIsEmpty: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticExists(condition)
 	},
-	contract.FilterOperatorMatchPhrase: func(condition contract.FilterCondition) map[string]any {
+	contract.FilterOperatorIsNotEmpty: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
+		return elasticExists(condition)
+	},
+	contract.FilterOperatorIn:    elasticTerms,
+	contract.FilterOperatorNotIn: elasticTerms,
+	contract.FilterOperatorMatchPhrase: func(condition contract.FilterCondition, mapping ElasticFieldMapping) map[string]any {
 		return map[string]any{
 			"match_phrase": map[string]any{
 				condition.Field: fmt.Sprint(condition.Value),
@@ -228,42 +287,66 @@ var conditionResolversElastic = map[contract.FilterOperator]func(contract.Filter
 	},
 }
 
-func transformConditionElastic(condition contract.FilterCondition, positiveConditions *[]map[string]any, negativeConditions *[]map[string]any) {
+func transformConditionElastic(condition contract.FilterCondition, positiveConditions *[]map[string]any, negativeConditions *[]map[string]any, context *elasticContext) *contract.Error {
 	if condition.Field == "" || condition.Operator == "" {
-		return
+		return nil
+	}
+	resolver, isSupported := conditionResolversElastic[condition.Operator]
+	if !isSupported {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("unsupported operator %s", condition.Operator))
+	}
+	mapping := context.fieldMapping(condition)
+	if slices.Contains(elasticPatternOperators, condition.Operator) && mapping.Type != ElasticFieldTypeText && mapping.Type != ElasticFieldTypeKeyword {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("operator %s is not supported by the %s field %q", condition.Operator, mapping.Type, condition.Field))
+	}
+	if slices.Contains(elasticWildcardOperators, condition.Operator) && mapping.isFullText() {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("operator %s needs a keyword sub-field of the text field %q", condition.Operator, condition.Field))
+	}
+	outputCondition := resolver(condition, mapping)
+	for index := len(mapping.NestedPaths) - 1; index >= 0; index-- {
+		outputCondition = map[string]any{
+			"nested": map[string]any{
+				"path":  mapping.NestedPaths[index],
+				"query": outputCondition,
+			},
+		}
 	}
-	outputCondition := conditionResolversElastic[condition.Operator](condition)
 	if condition.IsNegative() {
 		*negativeConditions = append(*negativeConditions, outputCondition)
-		return
+		return nil
 	}
 	*positiveConditions = append(*positiveConditions, outputCondition)
+	return nil
 }
 
-func transformConditionsElastic(conditions contract.FilterConditions) ([]map[string]any, []map[string]any) {
+func transformConditionsElastic(conditions contract.FilterConditions, context *elasticContext) ([]map[string]any, []map[string]any, *contract.Error) {
 	if conditions.IsEmpty() {
-		return nil, nil
+		return nil, nil, nil
 	}
 	var positiveConditions []map[string]any
 	var negativeConditions []map[string]any
 	if conditions.Filters != nil {
 		for _, filter := range conditions.Filters {
 			var condition = make(map[string]any)
-			transformFiltersElastic(filter, &condition)
+			if err := transformFiltersElastic(filter, &condition, context); err != nil {
+				return nil, nil, err
+			}
 			positiveConditions = append(positiveConditions, condition)
 		}
 	}
 	if conditions.Conditions != nil {
 		for _, condition := range conditions.Conditions {
-			transformConditionElastic(condition, &positiveConditions, &negativeConditions)
+			if err := transformConditionElastic(condition, &positiveConditions, &negativeConditions, context); err != nil {
+				return nil, nil, err
+			}
 		}
 	}
-	return positiveConditions, negativeConditions
+	return positiveConditions, negativeConditions, nil
 }
 
-func transformFiltersElastic(filters contract.Filters, target *map[string]any) {
+func transformFiltersElastic(filters contract.Filters, target *map[string]any, context *elasticContext) *contract.Error {
 	if filters.IsEmpty() {
-		return
+		return nil
 	}
 	var outputFilters = make(map[string]any)
 	logic := "must"
@@ -271,7 +354,10 @@ func transformFiltersElastic(filters contract.Filters, target *map[string]any) {
 		logic = "should"
 		outputFilters["minimum_should_match"] = 1
 	}
-	positiveConditions, negativeConditions := transformConditionsElastic(filters.Conditions)
+	positiveConditions, negativeConditions, err := transformConditionsElastic(filters.Conditions, context)
+	if err != nil {
+		return err
+	}
 	if positiveConditions != nil {
 		outputFilters[logic] = positiveConditions
 	}
@@ -293,14 +379,23 @@ func transformFiltersElastic(filters contract.Filters, target *map[string]any) {
 	if len(outputFilters) > 0 {
 		(*target)["bool"] = outputFilters
 	}
+	return nil
 }
 
 type ElasticOutputTransformer struct {
+	// Fields describes the fields of the index, the fields missing here use DefaultField
+	Fields map[string]ElasticFieldMapping
+	// DefaultField is used for the fields missing in Fields; if it is nil, the type is guessed from the value
+	// (strings use the ElasticDefaultKeywordSubField sub-field)
+	DefaultField *ElasticFieldMapping
 }
 
 func (t *ElasticOutputTransformer) Transform(input contract.Filters) (*ElasticOutput, *contract.Error) {
 	var transformedData = make(map[string]any)
-	transformFiltersElastic(input, &transformedData)
+	context := &elasticContext{fields: t.Fields, defaultField: t.DefaultField}
+	if err := transformFiltersElastic(input, &transformedData, context); err != nil {
+		return nil, err
+	}
 
 	var output ElasticOutput
 	if len(transformedData) == 0 {
@@ -567,6 +567,26 @@ func Test_transformConditionElastic(t *testing.T) {
 			},
 			wantNegative: &[]map[string]any{},
 		},
+		{
+			name: "in coerced numbers",
+			args: args{
+				condition: contract.FilterCondition{
+					Field:    "key",
+					Operator: contract.FilterOperatorIn,
+					Value:    []any{int64(1), int64(2)},
+				},
+				positiveConditions: &[]map[string]any{},
+				negativeConditions: &[]map[string]any{},
+			},
+			wantPositive: &[]map[string]any{
+				{
+					"terms": map[string]any{
+						"key": []any{int64(1), int64(2)},
+					},
+				},
+			},
+			wantNegative: &[]map[string]any{},
+		},
 		{
 			name: "not equal",
 			args: args{
@@ -1212,7 +1232,9 @@ func Test_transformConditionElastic(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			transformConditionElastic(tt.args.condition, tt.args.positiveConditions, tt.args.negativeConditions)
+			if err := transformConditionElastic(tt.args.condition, tt.args.positiveConditions, tt.args.negativeConditions, &elasticContext{}); err != nil {
+				t.Fatalf("transformConditionElastic() error = %v", err)
+			}
 			if !reflect.DeepEqual(tt.args.positiveConditions, tt.wantPositive) {
 				t.Errorf("transformConditionElastic() positive: got = %v, want %v", tt.args.positiveConditions, tt.wantPositive)
 			}
@@ -1222,3 +1244,114 @@ func Test_transformConditionElastic(t *testing.T) {
 		})
 	}
 }
+
+func TestElasticOutputTransformer_TransformWithFieldMapping(t1 *testing.T) {
+	transformer := &ElasticOutputTransformer{
+		Fields: map[string]ElasticFieldMapping{
+			"name":    {Type: ElasticFieldTypeText, KeywordSubField: "keyword"},
+			"title":   {Type: ElasticFieldTypeText},
+			"price":   {Type: ElasticFieldTypeNumeric},
+			"created": {Type: ElasticFieldTypeDate},
+		},
+		DefaultField: &ElasticFieldMapping{Type: ElasticFieldTypeKeyword},
+	}
+	tests := []struct {
+		name      string
+		condition contract.FilterCondition
+		want      map[string]any
+		wantErr   *contract.Error
+	}{
+		{
+			name:      "term on the keyword sub-field",
+			condition: contract.FilterCondition{Field: "name", Operator: contract.FilterOperatorEqual, Value: "Jo"},
+			want:      map[string]any{"term": map[string]any{"name.keyword": "Jo"}},
+		},
+		{
+			name:      "wildcard on the keyword sub-field",
+			condition: contract.FilterCondition{Field: "name", Operator: contract.FilterOperatorContains, Value: "Jo"},
+			want:      map[string]any{"wildcard": map[string]any{"name.keyword": "*Jo*"}},
+		},
+		{
+			name:      "range on the keyword sub-field",
+			condition: contract.FilterCondition{Field: "name", Operator: contract.FilterOperatorGreaterThan, Value: "J"},
+			want:      map[string]any{"range": map[string]any{"name.keyword": map[string]any{"gt": "J"}}},
+		},
+		{
+			name:      "match phrase on the text field",
+			condition: contract.FilterCondition{Field: "name", Operator: contract.FilterOperatorMatchPhrase, Value: "Jo Doe"},
+			want:      map[string]any{"match_phrase": map[string]any{"name": "Jo Doe"}},
+		},
+		{
+			name:      "equal on a text field without a keyword sub-field",
+			condition: contract.FilterCondition{Field: "title", Operator: contract.FilterOperatorEqual, Value: "Go"},
+			want:      map[string]any{"match_phrase": map[string]any{"title": "Go"}},
+		},
+		{
+			name:      "in on a text field without a keyword sub-field",
+			condition: contract.FilterCondition{Field: "title", Operator: contract.FilterOperatorIn, Value: []string{"Go", "Rust"}},
+			want: map[string]any{"bool": map[string]any{
+				"should":               []map[string]any{{"match_phrase": map[string]any{"title": "Go"}}, {"match_phrase": map[string]any{"title": "Rust"}}},
+				"minimum_should_match": 1,
+			}},
+		},
+		{
+			name:      "begins on a text field without a keyword sub-field",
+			condition: contract.FilterCondition{Field: "title", Operator: contract.FilterOperatorBegins, Value: "Go"},
+			want:      map[string]any{"match_phrase_prefix": map[string]any{"title": "Go"}},
+		},
+		{
+			name:      "contains on a text field without a keyword sub-field",
+			condition: contract.FilterCondition{Field: "title", Operator: contract.FilterOperatorContains, Value: "Go"},
+			wantErr:   contract.NewError(contract.NonWriteableOutputData, `operator contains needs a keyword sub-field of the text field "title"`),
+		},
+		{
+			name:      "ends on a text field without a keyword sub-field",
+			condition: contract.FilterCondition{Field: "title", Operator: contract.FilterOperatorEnds, Value: "Go"},
+			wantErr:   contract.NewError(contract.NonWriteableOutputData, `operator ends needs a keyword sub-field of the text field "title"`),
+		},
+		{
+			name:      "numeric string",
+			condition: contract.FilterCondition{Field: "price", Operator: contract.FilterOperatorEqual, Value: "10"},
+			want:      map[string]any{"term": map[string]any{"price": "10"}},
+		},
+		{
+			name:      "date",
+			condition: contract.FilterCondition{Field: "created", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: "2024-01-01"},
+			want:      map[string]any{"range": map[string]any{"created": map[string]any{"gte": "2024-01-01"}}},
+		},
+		{
+			name:      "default field",
+			condition: contract.FilterCondition{Field: "code", Operator: contract.FilterOperatorBegins, Value: "AB"},
+			want:      map[string]any{"prefix": map[string]any{"code": "AB"}},
+		},
+		{
+			name:      "pattern on a numeric field",
+			condition: contract.FilterCondition{Field: "price", Operator: contract.FilterOperatorContains, Value: "1"},
+			wantErr:   contract.NewError(contract.NonWriteableOutputData, `operator contains is not supported by the numeric field "price"`),
+		},
+		{
+			name:      "unsupported operator",
+			condition: contract.FilterCondition{Field: "code", Operator: "unknown", Value: "AB"},
+			wantErr:   contract.NewError(contract.NonWriteableOutputData, "unsupported operator unknown"),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			got, err := transformer.Transform(contract.Filters{
+				Logic:      contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{Conditions: []contract.FilterCondition{tt.condition}},
+			})
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			want, _ := contract.NewInputOutputType(map[string]any{"bool": map[string]any{"must": []map[string]any{tt.want}}}, &ElasticOutput{})
+			if !reflect.DeepEqual(got, want) {
+				t1.Errorf("Transform() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// elasticMappingFieldTypes maps the Elasticsearch field types to the field types of the output, fields of other types
+// (e.g. geo_point or binary) are left out of the mapping
+var elasticMappingFieldTypes = map[string]ElasticFieldType{
+	"text":             ElasticFieldTypeText,
+	"match_only_text":  ElasticFieldTypeText,
+	"keyword":          ElasticFieldTypeKeyword,
+	"constant_keyword": ElasticFieldTypeKeyword,
+	"wildcard":         ElasticFieldTypeKeyword,
+	"ip":               ElasticFieldTypeKeyword,
+	"long":             ElasticFieldTypeNumeric,
+	"integer":          ElasticFieldTypeNumeric,
+	"short":            ElasticFieldTypeNumeric,
+	"byte":             ElasticFieldTypeNumeric,
+	"double":           ElasticFieldTypeNumeric,
+	"float":            ElasticFieldTypeNumeric,
+	"half_float":       ElasticFieldTypeNumeric,
+	"scaled_float":     ElasticFieldTypeNumeric,
+	"unsigned_long":    ElasticFieldTypeNumeric,
+	"date":             ElasticFieldTypeDate,
+	"date_nanos":       ElasticFieldTypeDate,
+	"boolean":          ElasticFieldTypeBoolean,
+}
+
+type elasticMappingProperty struct {
+	Type       string                            `json:"type"`
+	Properties map[string]elasticMappingProperty `json:"properties"`
+	Fields     map[string]elasticMappingProperty `json:"fields"`
+}
+
+type elasticMappingProperties struct {
+	Properties map[string]elasticMappingProperty `json:"properties"`
+}
+
+// elasticMappingParameters are the top-level mapping parameters and metadata fields, they are not mapping types of
+// Elasticsearch 6 (the underscore prefix can't be used to tell them apart as the type is usually called `_doc`)
+var elasticMappingParameters = []string{
+	"dynamic", "dynamic_templates", "dynamic_date_formats", "date_detection", "numeric_detection", "runtime", "subobjects",
+	"_source", "_meta", "_routing", "_field_names", "_all", "_size", "_data_stream_timestamp",
+}
+
+// elasticIndexProperties reads both the typeless mappings and the mappings of Elasticsearch 6 (`{"_doc": {"properties": ...}}`);
+// mappings without properties (e.g. only `{"dynamic": "strict"}`) have no fields
+func elasticIndexProperties(index string, rawMappings json.RawMessage) (map[string]elasticMappingProperty, error) {
+	var mappings map[string]json.RawMessage
+	if err := json.Unmarshal(rawMappings, &mappings); err != nil {
+		return nil, fmt.Errorf("invalid mappings of index %q: %w", index, err)
+	}
+	properties := make(map[string]elasticMappingProperty)
+	if _, isTypeless := mappings["properties"]; isTypeless {
+		var typelessMapping elasticMappingProperties
+		if err := json.Unmarshal(rawMappings, &typelessMapping); err != nil {
+			return nil, fmt.Errorf("invalid mappings of index %q: %w", index, err)
+		}
+		return typelessMapping.Properties, nil
+	}
+	for name, rawMapping := range mappings {
+		if slices.Contains(elasticMappingParameters, name) {
+			continue
+		}
+		// a type is an object with properties
+		var typedMapping map[string]json.RawMessage
+		if err := json.Unmarshal(rawMapping, &typedMapping); err != nil || typedMapping["properties"] == nil {
+			continue
+		}
+		var typedProperties elasticMappingProperties
+		if err := json.Unmarshal(rawMapping, &typedProperties); err != nil {
+			return nil, fmt.Errorf("invalid mappings of index %q: %w", index, err)
+		}
+		for name, property := range typedProperties.Properties {
+			properties[name] = property
+		}
+	}
+	return properties, nil
+}
+
+// elasticKeywordSubField returns the first (by name) keyword multi-field of a text field
+func elasticKeywordSubField(property elasticMappingProperty) string {
+	var subFields []string
+	for name, subProperty := range property.Fields {
+		if elasticMappingFieldTypes[subProperty.Type] == ElasticFieldTypeKeyword {
+			subFields = append(subFields, name)
+		}
+	}
+	slices.Sort(subFields)
+	if len(subFields) == 0 {
+		return ""
+	}
+	return subFields[0]
+}
+
+func collectElasticFields(properties map[string]elasticMappingProperty, prefix string, nestedPaths []string, fields map[string]ElasticFieldMapping) {
+	for name, property := range properties {
+		path := joinElasticFieldPath(prefix, name)
+		if property.Properties != nil {
+			childNestedPaths := nestedPaths
+			if property.Type == "nested" {
+				childNestedPaths = append(slices.Clone(nestedPaths), path)
+			}
+			collectElasticFields(property.Properties, path, childNestedPaths, fields)
+			continue
+		}
+		fieldType, isSupported := elasticMappingFieldTypes[property.Type]
+		if !isSupported {
+			continue
+		}
+		mapping := ElasticFieldMapping{Type: fieldType, NestedPaths: nestedPaths}
+		if fieldType == ElasticFieldTypeText {
+			mapping.KeywordSubField = elasticKeywordSubField(property)
+		}
+		fields[path] = mapping
+		// the multi-fields can be used in the filters directly as well (e.g. `name.keyword`)
+		for subName, subProperty := range property.Fields {
+			if subFieldType, isSupported := elasticMappingFieldTypes[subProperty.Type]; isSupported {
+				fields[joinElasticFieldPath(path, subName)] = ElasticFieldMapping{Type: subFieldType, NestedPaths: nestedPaths}
+			}
+		}
+	}
+}
+
+func joinElasticFieldPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", prefix, name)
+}
+
+// NewElasticFieldsFromJson derives ElasticOutputTransformer.Fields from the response of `GET /<index>/_mapping`;
+// object fields are flattened into dotted paths, fields inside `nested` objects get their NestedPaths,
+// and the mappings of several indices (e.g. `GET /logs-*/_mapping`) are merged if they don't conflict
+func NewElasticFieldsFromJson(data []byte) (map[string]ElasticFieldMapping, error) {
+	var response map[string]struct {
+		Mappings json.RawMessage `json:"mappings"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	indices := make([]string, 0, len(response))
+	for index := range response {
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+
+	fields := make(map[string]ElasticFieldMapping)
+	fieldIndices := make(map[string]string)
+	for _, index := range indices {
+		if response[index].Mappings == nil {
+			return nil, fmt.Errorf("missing mappings of index %q", index)
+		}
+		properties, err := elasticIndexProperties(index, response[index].Mappings)
+		if err != nil {
+			return nil, err
+		}
+		indexFields := make(map[string]ElasticFieldMapping)
+		collectElasticFields(properties, "", nil, indexFields)
+		for field, mapping := range indexFields {
+			if existing, isKnown := fields[field]; isKnown && !isSameElasticFieldMapping(existing, mapping) {
+				return nil, fmt.Errorf("field %q is mapped differently in indices %q and %q", field, fieldIndices[field], index)
+			}
+			fields[field] = mapping
+			fieldIndices[field] = index
+		}
+	}
+	return fields, nil
+}
+
+// NewElasticFieldsFromFile reads the response of `GET /<index>/_mapping` stored in a file, see NewElasticFieldsFromJson
+func NewElasticFieldsFromFile(path string) (map[string]ElasticFieldMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewElasticFieldsFromJson(data)
+}
+
+func isSameElasticFieldMapping(a ElasticFieldMapping, b ElasticFieldMapping) bool {
+	return a.Type == b.Type && a.KeywordSubField == b.KeywordSubField && slices.Equal(a.NestedPaths, b.NestedPaths)
+}
@@ -0,0 +1,174 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+var testElasticMapping = []byte(`{
+	"products": {
+		"mappings": {
+			"properties": {
+				"name": {"type": "text", "fields": {"raw": {"type": "keyword"}, "ngram": {"type": "text"}}},
+				"description": {"type": "text"},
+				"sku": {"type": "keyword"},
+				"price": {"type": "scaled_float", "scaling_factor": 100},
+				"created_at": {"type": "date"},
+				"active": {"type": "boolean"},
+				"location": {"type": "geo_point"},
+				"meta": {"properties": {"source": {"type": "keyword"}}},
+				"variants": {
+					"type": "nested",
+					"properties": {
+						"color": {"type": "keyword"},
+						"stock": {"type": "nested", "properties": {"quantity": {"type": "integer"}}}
+					}
+				}
+			}
+		}
+	}
+}`)
+
+var testElasticFields = map[string]ElasticFieldMapping{
+	"name":                    {Type: ElasticFieldTypeText, KeywordSubField: "raw"},
+	"name.raw":                {Type: ElasticFieldTypeKeyword},
+	"name.ngram":              {Type: ElasticFieldTypeText},
+	"description":             {Type: ElasticFieldTypeText},
+	"sku":                     {Type: ElasticFieldTypeKeyword},
+	"price":                   {Type: ElasticFieldTypeNumeric},
+	"created_at":              {Type: ElasticFieldTypeDate},
+	"active":                  {Type: ElasticFieldTypeBoolean},
+	"meta.source":             {Type: ElasticFieldTypeKeyword},
+	"variants.color":          {Type: ElasticFieldTypeKeyword, NestedPaths: []string{"variants"}},
+	"variants.stock.quantity": {Type: ElasticFieldTypeNumeric, NestedPaths: []string{"variants", "variants.stock"}},
+}
+
+func TestNewElasticFieldsFromJson(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    map[string]ElasticFieldMapping
+		wantErr bool
+	}{
+		{
+			name:    "mapping",
+			data:    testElasticMapping,
+			want:    testElasticFields,
+			wantErr: false,
+		},
+		{
+			name:    "mapping with a type (Elasticsearch 6)",
+			data:    []byte(`{"products": {"mappings": {"_doc": {"properties": {"sku": {"type": "keyword"}}}}}}`),
+			want:    map[string]ElasticFieldMapping{"sku": {Type: ElasticFieldTypeKeyword}},
+			wantErr: false,
+		},
+		{
+			name:    "mapping without properties",
+			data:    []byte(`{"products": {"mappings": {"dynamic": "strict", "_source": {"enabled": false}, "dynamic_templates": [{"strings": {"match_mapping_type": "string", "mapping": {"type": "keyword"}}}]}}}`),
+			want:    map[string]ElasticFieldMapping{},
+			wantErr: false,
+		},
+		{
+			name:    "mapping with a type and parameters (Elasticsearch 6)",
+			data:    []byte(`{"products": {"mappings": {"_doc": {"dynamic": "strict", "_source": {"enabled": true}, "properties": {"sku": {"type": "keyword"}}}}}}`),
+			want:    map[string]ElasticFieldMapping{"sku": {Type: ElasticFieldTypeKeyword}},
+			wantErr: false,
+		},
+		{
+			name:    "index without properties among other indices",
+			data:    []byte(`{"logs-1": {"mappings": {"dynamic": "strict"}}, "logs-2": {"mappings": {"date_detection": false, "runtime": {"day": {"type": "keyword"}}, "properties": {"level": {"type": "keyword"}}}}}`),
+			want:    map[string]ElasticFieldMapping{"level": {Type: ElasticFieldTypeKeyword}},
+			wantErr: false,
+		},
+		{
+			name:    "several indices",
+			data:    []byte(`{"logs-1": {"mappings": {"properties": {"level": {"type": "keyword"}}}}, "logs-2": {"mappings": {"properties": {"level": {"type": "keyword"}, "took": {"type": "long"}}}}}`),
+			want:    map[string]ElasticFieldMapping{"level": {Type: ElasticFieldTypeKeyword}, "took": {Type: ElasticFieldTypeNumeric}},
+			wantErr: false,
+		},
+		{
+			name:    "conflicting indices",
+			data:    []byte(`{"logs-1": {"mappings": {"properties": {"level": {"type": "keyword"}}}}, "logs-2": {"mappings": {"properties": {"level": {"type": "long"}}}}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "missing mappings",
+			data:    []byte(`{"products": {"settings": {}}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			data:    []byte(`{"products": `),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewElasticFieldsFromJson(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewElasticFieldsFromJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewElasticFieldsFromJson() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewElasticFieldsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(path, testElasticMapping, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewElasticFieldsFromFile(path)
+	if err != nil {
+		t.Fatalf("NewElasticFieldsFromFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, testElasticFields) {
+		t.Errorf("NewElasticFieldsFromFile() got = %v, want %v", got, testElasticFields)
+	}
+	if _, err := NewElasticFieldsFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("NewElasticFieldsFromFile() expected an error for a missing file")
+	}
+}
+
+func TestElasticOutputTransformer_TransformNested(t *testing.T) {
+	transformer := &ElasticOutputTransformer{Fields: testElasticFields}
+	got, err := transformer.Transform(contract.Filters{
+		Logic: contract.FilterLogicAnd,
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{
+				{Field: "variants.color", Operator: contract.FilterOperatorNotEqual, Value: "red"},
+				{Field: "variants.stock.quantity", Operator: contract.FilterOperatorGreaterThan, Value: 0},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	want, _ := contract.NewInputOutputType(map[string]any{"bool": map[string]any{
+		"must": []map[string]any{
+			{"nested": map[string]any{"path": "variants", "query": map[string]any{
+				"nested": map[string]any{"path": "variants.stock", "query": map[string]any{
+					"range": map[string]any{"variants.stock.quantity": map[string]any{"gt": 0}},
+				}},
+			}}},
+		},
+		"must_not": []map[string]any{
+			{"nested": map[string]any{"path": "variants", "query": map[string]any{
+				"term": map[string]any{"variants.color": "red"},
+			}}},
+		},
+	}}, &ElasticOutput{})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Transform() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,188 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+var expressionOperatorSymbols = map[contract.FilterOperator]string{
+	contract.FilterOperatorEqual:                   "=",
+	contract.FilterOperatorNotEqual:                "!=",
+	contract.FilterOperatorGreaterThan:             ">",
+	contract.FilterOperatorGreaterThanOrEqual:      ">=",
+	contract.FilterOperatorGreaterThanOrEqualOrNil: ">=?",
+	contract.FilterOperatorLowerThan:               "<",
+	contract.FilterOperatorLowerThanOrEqual:        "<=",
+	contract.FilterOperatorLowerThanOrEqualOrNil:   "<=?",
+	contract.FilterOperatorBegins:                  "^=",
+	contract.FilterOperatorContains:                "~",
+	contract.FilterOperatorNotContains:             "!~",
+	contract.FilterOperatorEnds:                    "$=",
+	contract.FilterOperatorMatchPhrase:             "~~",
+	contract.FilterOperatorIsNil:                   "IS NULL",
+	contract.FilterOperatorIsNotNil:                "IS NOT NULL",
+	contract.FilterOperatorIsEmpty:                 "IS EMPTY",
+	contract.FilterOperatorIsNotEmpty:              "IS NOT EMPTY",
+	contract.FilterOperatorIn:                      "IN",
+	contract.FilterOperatorNotIn:                   "NOT IN",
+}
+
+var expressionIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+var expressionKeywords = []string{"AND", "OR", "NOT", "IN", "IS", "NULL", "EMPTY", "TRUE", "FALSE"}
+
+// the expression lexer takes the character following a backslash literally, so only quotes and backslashes are escaped
+var expressionStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+type ExpressionOutput struct {
+	contract.InputOutputType[string]
+}
+
+func (o *ExpressionOutput) GetDataJson() ([]byte, error) {
+	rawData, err := o.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == "" {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+func (o *ExpressionOutput) GetDataString() (string, error) {
+	return o.GetData()
+}
+
+func formatExpressionField(field string) string {
+	if expressionIdentifierPattern.MatchString(field) && !slices.Contains(expressionKeywords, strings.ToUpper(field)) {
+		return field
+	}
+	return fmt.Sprintf("`%s`", strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(field))
+}
+
+func formatExpressionValue(value any) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(typedValue)
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(typedValue), 'f', -1, 32)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(typedValue)
+	case string:
+		return fmt.Sprintf(`"%s"`, expressionStringEscaper.Replace(typedValue))
+	case time.Time:
+		return fmt.Sprintf(`"%s"`, typedValue.Format(time.RFC3339Nano))
+	}
+	return fmt.Sprintf(`"%s"`, expressionStringEscaper.Replace(fmt.Sprint(value)))
+}
+
+func formatExpressionList(condition contract.FilterCondition) string {
+	var items []string
+	for _, value := range condition.ValueList() {
+		items = append(items, formatExpressionValue(value))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(items, ", "))
+}
+
+func transformConditionExpression(condition contract.FilterCondition, outputConditions *[]string) *contract.Error {
+	if condition.Field == "" || condition.Operator == "" {
+		return nil
+	}
+	symbol, isSupported := expressionOperatorSymbols[condition.Operator]
+	if !isSupported {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("unsupported operator %s", condition.Operator))
+	}
+	field := formatExpressionField(condition.Field)
+	switch condition.Operator {
+	case contract.FilterOperatorIsNil, contract.FilterOperatorIsNotNil, contract.FilterOperatorIsEmpty, contract.FilterOperatorIsNotEmpty:
+		*outputConditions = append(*outputConditions, fmt.Sprintf("%s %s", field, symbol))
+	case contract.FilterOperatorIn, contract.FilterOperatorNotIn:
+		*outputConditions = append(*outputConditions, fmt.Sprintf("%s %s %s", field, symbol, formatExpressionList(condition)))
+	default:
+		*outputConditions = append(*outputConditions, fmt.Sprintf("%s %s %s", field, symbol, formatExpressionValue(condition.Value)))
+	}
+	return nil
+}
+
+// renderFiltersExpression returns the joined conditions and the number of joined terms (a group with a single term
+// returns the number of terms of that term), nested groups of several terms are wrapped in parentheses when they have siblings
+func renderFiltersExpression(filters contract.Filters) (string, int, *contract.Error) {
+	if filters.IsEmpty() {
+		return "", 0, nil
+	}
+	var outputConditions []string
+	var outputTerms []int
+	for _, filter := range filters.Conditions.Filters {
+		condition, terms, err := renderFiltersExpression(filter)
+		if err != nil {
+			return "", 0, err
+		}
+		if condition == "" {
+			continue
+		}
+		outputConditions = append(outputConditions, condition)
+		outputTerms = append(outputTerms, terms)
+	}
+	for _, condition := range filters.Conditions.Conditions {
+		if err := transformConditionExpression(condition, &outputConditions); err != nil {
+			return "", 0, err
+		}
+	}
+	if len(outputConditions) == 1 {
+		if len(outputTerms) == 1 {
+			return outputConditions[0], outputTerms[0], nil
+		}
+		return outputConditions[0], 1, nil
+	}
+	for index, terms := range outputTerms {
+		if terms > 1 {
+			outputConditions[index] = fmt.Sprintf("(%s)", outputConditions[index])
+		}
+	}
+	logic := contract.FilterLogicAnd
+	if filters.Logic == contract.FilterLogicOr {
+		logic = contract.FilterLogicOr
+	}
+	return strings.Join(outputConditions, fmt.Sprintf(" %s ", strings.ToUpper(string(logic)))), len(outputConditions), nil
+}
+
+func transformFiltersExpression(filters contract.Filters, target *string) *contract.Error {
+	expression, _, err := renderFiltersExpression(filters)
+	if err != nil {
+		return err
+	}
+	*target = expression
+	return nil
+}
+
+type ExpressionOutputTransformer struct {
+}
+
+func (t *ExpressionOutputTransformer) Transform(input contract.Filters) (*ExpressionOutput, *contract.Error) {
+	var expression string
+	if err := transformFiltersExpression(input, &expression); err != nil {
+		return nil, err
+	}
+
+	var output ExpressionOutput
+	if expression == "" {
+		return &output, nil
+	}
+
+	err := output.SetData(expression)
+	if err != nil {
+		return nil, contract.NewError(contract.NonWriteableOutputData, err.Error())
+	}
+	return &output, nil
+}
@@ -0,0 +1,241 @@
+package output
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+var testOutputExpression0, _ = contract.NewInputOutputType(`key = "val"`, &ExpressionOutput{})
+var testOutputExpression1, _ = contract.NewInputOutputType(`(key = "val" OR key2 != "val2") AND key3 IS NOT NULL`, &ExpressionOutput{})
+
+func TestExpressionOutputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   contract.Filters
+		want    *ExpressionOutput
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty filters",
+			input:   contract.Filters{},
+			want:    &ExpressionOutput{},
+			wantErr: nil,
+		},
+		{
+			name: "with data",
+			input: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+					},
+				},
+			},
+			want:    testOutputExpression0,
+			wantErr: nil,
+		},
+		{
+			name: "with nested data",
+			input: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key3", Operator: contract.FilterOperatorIsNotNil},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+									{Field: "key2", Operator: contract.FilterOperatorNotEqual, Value: "val2"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    testOutputExpression1,
+			wantErr: nil,
+		},
+		{
+			name: "all operators",
+			input: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "a", Operator: contract.FilterOperatorGreaterThan, Value: 1.5},
+						{Field: "b", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 2},
+						{Field: "c", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 3.0},
+						{Field: "d", Operator: contract.FilterOperatorLowerThan, Value: -4.0},
+						{Field: "e", Operator: contract.FilterOperatorLowerThanOrEqual, Value: 5.0},
+						{Field: "f", Operator: contract.FilterOperatorLowerThanOrEqualOrNil, Value: 6.0},
+						{Field: "g", Operator: contract.FilterOperatorBegins, Value: "x"},
+						{Field: "h", Operator: contract.FilterOperatorContains, Value: "y"},
+						{Field: "i", Operator: contract.FilterOperatorNotContains, Value: "z"},
+						{Field: "j", Operator: contract.FilterOperatorEnds, Value: "w"},
+						{Field: "k", Operator: contract.FilterOperatorMatchPhrase, Value: "a b"},
+						{Field: "l", Operator: contract.FilterOperatorIsNil},
+						{Field: "m", Operator: contract.FilterOperatorIsEmpty},
+						{Field: "n", Operator: contract.FilterOperatorIsNotEmpty},
+						{Field: "o", Operator: contract.FilterOperatorIn, Value: []any{1.0, "2", true, nil}},
+						{Field: "p", Operator: contract.FilterOperatorNotIn, Value: []string{"a", "b"}},
+						{Field: "q", Operator: contract.FilterOperatorIn, Value: "single"},
+					},
+				},
+			},
+			want: func() *ExpressionOutput {
+				output, _ := contract.NewInputOutputType(`a > 1.5 OR b >= 2 OR c >=? 3 OR d < -4 OR e <= 5 OR f <=? 6 OR g ^= "x" OR h ~ "y" OR i !~ "z" OR j $= "w" OR k ~~ "a b" OR l IS NULL OR m IS EMPTY OR n IS NOT EMPTY OR o IN (1, "2", true, null) OR p NOT IN ("a", "b") OR q IN ("single")`, &ExpressionOutput{})
+				return output
+			}(),
+			wantErr: nil,
+		},
+		{
+			name: "quoted fields and values",
+			input: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "my field", Operator: contract.FilterOperatorEqual, Value: `say "hi" \ bye`},
+						{Field: "or", Operator: contract.FilterOperatorEqual, Value: false},
+						{Field: "user.name", Operator: contract.FilterOperatorEqual, Value: "a`b"},
+					},
+				},
+			},
+			want: func() *ExpressionOutput {
+				output, _ := contract.NewInputOutputType("`my field` = \"say \\\"hi\\\" \\\\ bye\" AND `or` = false AND user.name = \"a`b\"", &ExpressionOutput{})
+				return output
+			}(),
+			wantErr: nil,
+		},
+		{
+			name: "with nested single-child groups",
+			input: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "c", Operator: contract.FilterOperatorEqual, Value: "3"},
+					},
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Filters: []contract.Filters{
+									{
+										Logic: contract.FilterLogicOr,
+										Conditions: contract.FilterConditions{
+											Conditions: []contract.FilterCondition{
+												{Field: "a", Operator: contract.FilterOperatorEqual, Value: "1"},
+												{Field: "b", Operator: contract.FilterOperatorEqual, Value: "2"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: func() *ExpressionOutput {
+				output, _ := contract.NewInputOutputType(`(a = "1" OR b = "2") AND c = "3"`, &ExpressionOutput{})
+				return output
+			}(),
+			wantErr: nil,
+		},
+		{
+			name: "unsupported operator",
+			input: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key", Operator: "like", Value: "val"},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: contract.NewError(contract.NonWriteableOutputData, "unsupported operator like"),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := &ExpressionOutputTransformer{}
+			got, err := t.Transform(tt.input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionOutput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name             string
+		expressionOutput ExpressionOutput
+		want             []byte
+		wantErr          bool
+	}{
+		{
+			name:             "empty",
+			expressionOutput: ExpressionOutput{},
+			want:             nil,
+			wantErr:          false,
+		},
+		{
+			name:             "with data",
+			expressionOutput: *testOutputExpression0,
+			want:             []byte(`"key = \"val\""`),
+			wantErr:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.expressionOutput.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpressionOutput_GetDataString(t *testing.T) {
+	tests := []struct {
+		name             string
+		expressionOutput ExpressionOutput
+		want             string
+		wantErr          bool
+	}{
+		{
+			name:             "empty",
+			expressionOutput: ExpressionOutput{},
+			want:             "",
+			wantErr:          false,
+		},
+		{
+			name:             "with nested data",
+			expressionOutput: *testOutputExpression1,
+			want:             `(key = "val" OR key2 != "val2") AND key3 IS NOT NULL`,
+			wantErr:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.expressionOutput.GetDataString()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetDataString() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
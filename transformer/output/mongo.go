@@ -0,0 +1,195 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+type MongoOutput struct {
+	contract.InputOutputType[map[string]any]
+}
+
+func (o *MongoOutput) GetDataJson() ([]byte, error) {
+	rawData, err := o.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if rawData == nil {
+		return nil, nil
+	}
+	return json.Marshal(rawData)
+}
+
+func (o *MongoOutput) GetDataString() (string, error) {
+	rawData, err := o.GetDataJson()
+	if err != nil {
+		return "", err
+	}
+	return string(rawData), nil
+}
+
+func mongoOperatorDocument(condition contract.FilterCondition, operator string, value any) map[string]any {
+	return map[string]any{
+		condition.Field: map[string]any{
+			operator: value,
+		},
+	}
+}
+
+// mongoRegex escapes the value, so that only the anchors are interpreted by the regex engine
+func mongoRegex(condition contract.FilterCondition, prefix string, suffix string) map[string]any {
+	return map[string]any{
+		"$regex": prefix + regexp.QuoteMeta(fmt.Sprint(condition.Value)) + suffix,
+	}
+}
+
+func mongoOrNil(condition contract.FilterCondition, operator string) map[string]any {
+	return map[string]any{
+		"$or": []map[string]any{
+			mongoOperatorDocument(condition, operator, condition.Value),
+			mongoOperatorDocument(condition, "$eq", nil),
+		},
+	}
+}
+
+// the values are always compared using an explicit operator, so that a document value (e.g. `{"$ne": null}`) is matched
+// literally instead of being interpreted as an operator; null is used instead of `$exists` as it also matches missing fields
+var conditionResolversMongo = map[contract.FilterOperator]func(contract.FilterCondition) map[string]any{
+	contract.FilterOperatorEqual: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$eq", condition.Value)
+	},
+	contract.FilterOperatorNotEqual: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$ne", condition.Value)
+	},
+	contract.FilterOperatorGreaterThan: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$gt", condition.Value)
+	},
+	contract.FilterOperatorGreaterThanOrEqual: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$gte", condition.Value)
+	},
+	contract.FilterOperatorGreaterThanOrEqualOrNil: func(condition contract.FilterCondition) map[string]any {
+		return mongoOrNil(condition, "$gte")
+	},
+	contract.FilterOperatorLowerThan: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$lt", condition.Value)
+	},
+	contract.FilterOperatorLowerThanOrEqual: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$lte", condition.Value)
+	},
+	contract.FilterOperatorLowerThanOrEqualOrNil: func(condition contract.FilterCondition) map[string]any {
+		return mongoOrNil(condition, "$lte")
+	},
+	contract.FilterOperatorBegins: func(condition contract.FilterCondition) map[string]any {
+		return map[string]any{condition.Field: mongoRegex(condition, "^", "")}
+	},
+	contract.FilterOperatorContains: func(condition contract.FilterCondition) map[string]any {
+		return map[string]any{condition.Field: mongoRegex(condition, "", "")}
+	},
+	contract.FilterOperatorNotContains: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$not", mongoRegex(condition, "", ""))
+	},
+	contract.FilterOperatorEnds: func(condition contract.FilterCondition) map[string]any {
+		return map[string]any{condition.Field: mongoRegex(condition, "", "$")}
+	},
+	contract.FilterOperatorIsNil: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$eq", nil)
+	},
+	contract.FilterOperatorIsNotNil: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$ne", nil)
+	},
+	contract.FilterOperatorIsEmpty: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$in", []any{nil, ""})
+	},
+	contract.FilterOperatorIsNotEmpty: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$nin", []any{nil, ""})
+	},
+	contract.FilterOperatorIn: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$in", condition.ValueList())
+	},
+	contract.FilterOperatorNotIn: func(condition contract.FilterCondition) map[string]any {
+		return mongoOperatorDocument(condition, "$nin", condition.ValueList())
+	},
+	contract.FilterOperatorMatchPhrase: func(condition contract.FilterCondition) map[string]any {
+		return map[string]any{condition.Field: mongoRegex(condition, "", "")}
+	},
+}
+
+// isValidMongoField rejects paths with empty segments and segments starting with `$` (Mongo would read them as operators)
+func isValidMongoField(field string) bool {
+	for _, segment := range strings.Split(field, ".") {
+		if segment == "" || strings.HasPrefix(segment, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+func transformConditionMongo(condition contract.FilterCondition, outputConditions *[]map[string]any) *contract.Error {
+	if condition.Field == "" || condition.Operator == "" {
+		return nil
+	}
+	if !isValidMongoField(condition.Field) {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("invalid field %q", condition.Field))
+	}
+	resolver, isSupported := conditionResolversMongo[condition.Operator]
+	if !isSupported {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("unsupported operator %s", condition.Operator))
+	}
+	*outputConditions = append(*outputConditions, resolver(condition))
+	return nil
+}
+
+func transformFiltersMongo(filters contract.Filters, target *map[string]any) *contract.Error {
+	if filters.IsEmpty() {
+		return nil
+	}
+	var outputConditions []map[string]any
+	for _, filter := range filters.Conditions.Filters {
+		var condition = make(map[string]any)
+		if err := transformFiltersMongo(filter, &condition); err != nil {
+			return err
+		}
+		if len(condition) > 0 {
+			outputConditions = append(outputConditions, condition)
+		}
+	}
+	for _, condition := range filters.Conditions.Conditions {
+		if err := transformConditionMongo(condition, &outputConditions); err != nil {
+			return err
+		}
+	}
+	if len(outputConditions) == 0 {
+		return nil
+	}
+	logic := "$and"
+	if filters.Logic == contract.FilterLogicOr {
+		logic = "$or"
+	}
+	(*target)[logic] = outputConditions
+	return nil
+}
+
+type MongoOutputTransformer struct {
+}
+
+func (t *MongoOutputTransformer) Transform(input contract.Filters) (*MongoOutput, *contract.Error) {
+	var transformedData = make(map[string]any)
+	if err := transformFiltersMongo(input, &transformedData); err != nil {
+		return nil, err
+	}
+
+	var output MongoOutput
+	if len(transformedData) == 0 {
+		return &output, nil
+	}
+
+	err := output.SetData(transformedData)
+	if err != nil {
+		return nil, contract.NewError(contract.NonWriteableOutputData, err.Error())
+	}
+	return &output, nil
+}
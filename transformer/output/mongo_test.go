@@ -0,0 +1,296 @@
+package output
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+)
+
+var testOutputMongo0, _ = contract.NewInputOutputType(map[string]any{
+	"$and": []map[string]any{
+		{"key": map[string]any{"$eq": "val"}},
+	},
+}, &MongoOutput{})
+var testOutputMongo1, _ = contract.NewInputOutputType(map[string]any{
+	"$and": []map[string]any{
+		{
+			"$or": []map[string]any{
+				{"key": map[string]any{"$eq": "val"}},
+				{"key2": map[string]any{"$ne": "val2"}},
+			},
+		},
+	},
+}, &MongoOutput{})
+var testOutputMongo2, _ = contract.NewInputOutputType(map[string]any{
+	"$or": []map[string]any{
+		{
+			"$and": []map[string]any{
+				{"key": map[string]any{"$eq": "val"}},
+				{"key2": map[string]any{"$nin": []any{nil, ""}}},
+			},
+		},
+		{
+			"$and": []map[string]any{
+				{"key3": map[string]any{"$regex": `va\.l3`}},
+				{"key4": map[string]any{"$gt": 123}},
+			},
+		},
+	},
+}, &MongoOutput{})
+
+func TestMongoOutputTransformer_Transform(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		input   contract.Filters
+		want    *MongoOutput
+		wantErr *contract.Error
+	}{
+		{
+			name:    "empty input",
+			input:   contract.Filters{},
+			want:    &MongoOutput{},
+			wantErr: nil,
+		},
+		{
+			name: "with data",
+			input: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+					},
+				},
+			},
+			want:    testOutputMongo0,
+			wantErr: nil,
+		},
+		{
+			name: "with nested data",
+			input: contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicOr,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+									{Field: "key2", Operator: contract.FilterOperatorNotEqual, Value: "val2"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    testOutputMongo1,
+			wantErr: nil,
+		},
+		{
+			name: "with complex data",
+			input: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Filters: []contract.Filters{
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+									{Field: "key2", Operator: contract.FilterOperatorIsNotEmpty},
+								},
+							},
+						},
+						{
+							Logic: contract.FilterLogicAnd,
+							Conditions: contract.FilterConditions{
+								Conditions: []contract.FilterCondition{
+									{Field: "key3", Operator: contract.FilterOperatorContains, Value: "va.l3"},
+									{Field: "key4", Operator: contract.FilterOperatorGreaterThan, Value: 123},
+								},
+							},
+						},
+					},
+				},
+			},
+			want:    testOutputMongo2,
+			wantErr: nil,
+		},
+		{
+			name: "invalid field - operator",
+			input: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "$where", Operator: contract.FilterOperatorEqual, Value: "val"},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: contract.NewError(contract.NonWriteableOutputData, `invalid field "$where"`),
+		},
+		{
+			name: "invalid field - empty path segment",
+			input: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "user..name", Operator: contract.FilterOperatorEqual, Value: "val"},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: contract.NewError(contract.NonWriteableOutputData, `invalid field "user..name"`),
+		},
+		{
+			name: "unsupported operator",
+			input: contract.Filters{
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "key", Operator: "unknown", Value: "val"},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: contract.NewError(contract.NonWriteableOutputData, "unsupported operator unknown"),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := MongoOutputTransformer{}
+			got, err := t.Transform(tt.input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMongoOutput_GetDataJson(t *testing.T) {
+	tests := []struct {
+		name        string
+		mongoOutput MongoOutput
+		want        []byte
+		wantErr     bool
+	}{
+		{
+			name:        "empty output",
+			mongoOutput: MongoOutput{},
+			want:        nil,
+			wantErr:     false,
+		},
+		{
+			name:        "with data",
+			mongoOutput: *testOutputMongo0,
+			want:        []byte(`{"$and":[{"key":{"$eq":"val"}}]}`),
+			wantErr:     false,
+		},
+		{
+			name:        "with complex data",
+			mongoOutput: *testOutputMongo2,
+			want:        []byte(`{"$or":[{"$and":[{"key":{"$eq":"val"}},{"key2":{"$nin":[null,""]}}]},{"$and":[{"key3":{"$regex":"va\\.l3"}},{"key4":{"$gt":123}}]}]}`),
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mongoOutput.GetDataJson()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDataJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDataJson() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_transformConditionMongo(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition contract.FilterCondition
+		want      []map[string]any
+	}{
+		{
+			name:      "equal",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorEqual, Value: map[string]any{"$ne": nil}},
+			want:      []map[string]any{{"key": map[string]any{"$eq": map[string]any{"$ne": nil}}}},
+		},
+		{
+			name:      "greater than or equal or nil",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 10},
+			want: []map[string]any{{"$or": []map[string]any{
+				{"key": map[string]any{"$gte": 10}},
+				{"key": map[string]any{"$eq": nil}},
+			}}},
+		},
+		{
+			name:      "lower than or equal",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorLowerThanOrEqual, Value: "2024-01-01"},
+			want:      []map[string]any{{"key": map[string]any{"$lte": "2024-01-01"}}},
+		},
+		{
+			name:      "begins",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorBegins, Value: "a+b"},
+			want:      []map[string]any{{"key": map[string]any{"$regex": `^a\+b`}}},
+		},
+		{
+			name:      "ends",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorEnds, Value: "(1)"},
+			want:      []map[string]any{{"key": map[string]any{"$regex": `\(1\)$`}}},
+		},
+		{
+			name:      "not contains",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorNotContains, Value: "a*"},
+			want:      []map[string]any{{"key": map[string]any{"$not": map[string]any{"$regex": `a\*`}}}},
+		},
+		{
+			name:      "match phrase",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorMatchPhrase, Value: "a b"},
+			want:      []map[string]any{{"key": map[string]any{"$regex": "a b"}}},
+		},
+		{
+			name:      "nil",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorIsNil},
+			want:      []map[string]any{{"key": map[string]any{"$eq": nil}}},
+		},
+		{
+			name:      "not nil",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorIsNotNil},
+			want:      []map[string]any{{"key": map[string]any{"$ne": nil}}},
+		},
+		{
+			name:      "empty",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorIsEmpty},
+			want:      []map[string]any{{"key": map[string]any{"$in": []any{nil, ""}}}},
+		},
+		{
+			name:      "in",
+			condition: contract.FilterCondition{Field: "user.tags", Operator: contract.FilterOperatorIn, Value: []string{"a", "b"}},
+			want:      []map[string]any{{"user.tags": map[string]any{"$in": []any{"a", "b"}}}},
+		},
+		{
+			name:      "not in single value",
+			condition: contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorNotIn, Value: 1},
+			want:      []map[string]any{{"key": map[string]any{"$nin": []any{1}}}},
+		},
+		{
+			name:      "empty condition",
+			condition: contract.FilterCondition{},
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []map[string]any
+			if err := transformConditionMongo(tt.condition, &got); err != nil {
+				t.Fatalf("transformConditionMongo() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("transformConditionMongo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
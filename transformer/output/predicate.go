@@ -0,0 +1,385 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+const PredicateDefaultTagName = "json"
+
+var errPredicateNotSerializable = errors.New("a predicate can't be converted to JSON")
+
+// predicateTimeLayouts are tried in order when a string is compared to a time.Time
+var predicateTimeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// Predicate reports whether the record matches the filters
+type Predicate func(record any) (bool, error)
+
+type PredicateOutput struct {
+	contract.InputOutputType[Predicate]
+	filters contract.Filters
+}
+
+func (o *PredicateOutput) GetDataJson() ([]byte, error) {
+	return nil, errPredicateNotSerializable
+}
+
+// GetDataString prints the filters using the syntax of the Expression output (for debugging)
+func (o *PredicateOutput) GetDataString() (string, error) {
+	var expression string
+	if err := transformFiltersExpression(o.filters, &expression); err != nil {
+		return "", err.Err
+	}
+	return expression, nil
+}
+
+func isPredicateNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+func predicateNumber(value reflect.Value) float64 {
+	switch {
+	case value.CanInt():
+		return float64(value.Int())
+	case value.CanUint():
+		return float64(value.Uint())
+	}
+	return value.Float()
+}
+
+func predicateString(value any) string {
+	reflectedValue := reflect.ValueOf(value)
+	switch {
+	case reflectedValue.Kind() == reflect.String:
+		return reflectedValue.String()
+	case isPredicateNumber(reflectedValue.Kind()):
+		return strconv.FormatFloat(predicateNumber(reflectedValue), 'f', -1, 64)
+	}
+	if typedValue, isTime := value.(time.Time); isTime {
+		return typedValue.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+func predicateTime(value any) (time.Time, error) {
+	if typedValue, isTime := value.(time.Time); isTime {
+		return typedValue, nil
+	}
+	if typedValue, isString := value.(string); isString {
+		for _, layout := range predicateTimeLayouts {
+			if parsed, err := time.Parse(layout, typedValue); err == nil {
+				return parsed, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("can't compare %v to a time", value)
+}
+
+func compareOrdered[T int | float64 | string](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePredicateValues converts the value of the condition to the type of the record value (similar to a database
+// converting a param to the type of the column), comparing non-scalar values (e.g. slices) is an error
+func comparePredicateValues(recordValue any, conditionValue any) (int, error) {
+	if recordTime, isTime := recordValue.(time.Time); isTime {
+		conditionTime, err := predicateTime(conditionValue)
+		if err != nil {
+			return 0, err
+		}
+		return recordTime.Compare(conditionTime), nil
+	}
+	record, condition := reflect.ValueOf(recordValue), reflect.ValueOf(conditionValue)
+	switch {
+	case isPredicateNumber(record.Kind()):
+		if isPredicateNumber(condition.Kind()) {
+			return compareOrdered(predicateNumber(record), predicateNumber(condition)), nil
+		}
+		if condition.Kind() == reflect.String {
+			number, err := strconv.ParseFloat(strings.TrimSpace(condition.String()), 64)
+			if err != nil {
+				return 0, fmt.Errorf("can't compare %q to a number", condition.String())
+			}
+			return compareOrdered(predicateNumber(record), number), nil
+		}
+	case record.Kind() == reflect.Bool:
+		conditionBool, isBool := conditionValue.(bool)
+		if condition.Kind() == reflect.String {
+			var err error
+			if conditionBool, err = strconv.ParseBool(condition.String()); err != nil {
+				return 0, fmt.Errorf("can't compare %q to a boolean", condition.String())
+			}
+			isBool = true
+		}
+		if isBool {
+			// false < true like in SQL
+			return compareOrdered(boolToInt(record.Bool()), boolToInt(conditionBool)), nil
+		}
+	case record.Kind() == reflect.String:
+		_, isTime := conditionValue.(time.Time)
+		if isTime || isPredicateNumber(condition.Kind()) || condition.Kind() == reflect.String || condition.Kind() == reflect.Bool {
+			return compareOrdered(record.String(), predicateString(conditionValue)), nil
+		}
+	default:
+		return 0, fmt.Errorf("can't compare a value of type %T", recordValue)
+	}
+	return 0, fmt.Errorf("can't compare %T to %T", recordValue, conditionValue)
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// isPredicateEmpty follows the SQL output (a comparison with an empty string literal), so only strings (including
+// named string types) can be empty
+func isPredicateEmpty(value any) bool {
+	reflectedValue := reflect.ValueOf(value)
+	return reflectedValue.Kind() == reflect.String && reflectedValue.Len() == 0
+}
+
+// the conditions follow the SQL semantics: any comparison (including neq, not-in, not-contains, empty and not-empty)
+// with a NULL (nil) value doesn't match, only nil and the OrNil variants match nil values
+type predicateConditionFunc func(recordValue any) (bool, error)
+
+func newPredicateComparison(condition contract.FilterCondition, matches func(int) bool) predicateConditionFunc {
+	return func(recordValue any) (bool, error) {
+		if recordValue == nil || condition.Value == nil {
+			return false, nil
+		}
+		comparison, err := comparePredicateValues(recordValue, condition.Value)
+		if err != nil {
+			return false, err
+		}
+		return matches(comparison), nil
+	}
+}
+
+func orPredicateNil(conditionFunc predicateConditionFunc) predicateConditionFunc {
+	return func(recordValue any) (bool, error) {
+		if recordValue == nil {
+			return true, nil
+		}
+		return conditionFunc(recordValue)
+	}
+}
+
+// newPredicateLike matches the string form of the value (LIKE works on the text representation of the column)
+func newPredicateLike(condition contract.FilterCondition, caseInsensitive bool, matches func(value string, pattern string) bool) predicateConditionFunc {
+	pattern := predicateString(condition.Value)
+	if caseInsensitive {
+		pattern = strings.ToLower(pattern)
+	}
+	return func(recordValue any) (bool, error) {
+		if recordValue == nil || condition.Value == nil {
+			return false, nil
+		}
+		value := predicateString(recordValue)
+		if caseInsensitive {
+			value = strings.ToLower(value)
+		}
+		return matches(value, pattern), nil
+	}
+}
+
+func newPredicateIn(condition contract.FilterCondition, negated bool) predicateConditionFunc {
+	values := condition.ValueList()
+	return func(recordValue any) (bool, error) {
+		if recordValue == nil {
+			return false, nil
+		}
+		hasNil := false
+		for _, value := range values {
+			if value == nil {
+				hasNil = true
+				continue
+			}
+			comparison, err := comparePredicateValues(recordValue, value)
+			if err != nil {
+				return false, err
+			}
+			if comparison == 0 {
+				return !negated, nil
+			}
+		}
+		// `x NOT IN (1, NULL)` is never true in SQL (the comparison with NULL is unknown)
+		return negated && !hasNil, nil
+	}
+}
+
+var conditionResolversPredicate = map[contract.FilterOperator]func(contract.FilterCondition, bool) predicateConditionFunc{
+	contract.FilterOperatorEqual: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateComparison(condition, func(comparison int) bool { return comparison == 0 })
+	},
+	contract.FilterOperatorNotEqual: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateComparison(condition, func(comparison int) bool { return comparison != 0 })
+	},
+	contract.FilterOperatorGreaterThan: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateComparison(condition, func(comparison int) bool { return comparison > 0 })
+	},
+	contract.FilterOperatorGreaterThanOrEqual: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateComparison(condition, func(comparison int) bool { return comparison >= 0 })
+	},
+	contract.FilterOperatorGreaterThanOrEqualOrNil: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return orPredicateNil(newPredicateComparison(condition, func(comparison int) bool { return comparison >= 0 }))
+	},
+	contract.FilterOperatorLowerThan: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateComparison(condition, func(comparison int) bool { return comparison < 0 })
+	},
+	contract.FilterOperatorLowerThanOrEqual: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateComparison(condition, func(comparison int) bool { return comparison <= 0 })
+	},
+	contract.FilterOperatorLowerThanOrEqualOrNil: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return orPredicateNil(newPredicateComparison(condition, func(comparison int) bool { return comparison <= 0 }))
+	},
+	contract.FilterOperatorBegins: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateLike(condition, caseInsensitiveLike, strings.HasPrefix)
+	},
+	contract.FilterOperatorContains: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateLike(condition, caseInsensitiveLike, strings.Contains)
+	},
+	contract.FilterOperatorNotContains: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateLike(condition, caseInsensitiveLike, func(value string, pattern string) bool {
+			return !strings.Contains(value, pattern)
+		})
+	},
+	contract.FilterOperatorEnds: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateLike(condition, caseInsensitiveLike, strings.HasSuffix)
+	},
+	contract.FilterOperatorIsNil: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return func(recordValue any) (bool, error) {
+			return recordValue == nil, nil
+		}
+	},
+	contract.FilterOperatorIsNotNil: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return func(recordValue any) (bool, error) {
+			return recordValue != nil, nil
+		}
+	},
+	contract.FilterOperatorIsEmpty: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return func(recordValue any) (bool, error) {
+			return isPredicateEmpty(recordValue), nil
+		}
+	},
+	contract.FilterOperatorIsNotEmpty: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return func(recordValue any) (bool, error) {
+			return recordValue != nil && !isPredicateEmpty(recordValue), nil
+		}
+	},
+	contract.FilterOperatorIn: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateIn(condition, false)
+	},
+	contract.FilterOperatorNotIn: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateIn(condition, true)
+	},
+	contract.FilterOperatorMatchPhrase: func(condition contract.FilterCondition, caseInsensitiveLike bool) predicateConditionFunc {
+		return newPredicateLike(condition, caseInsensitiveLike, strings.Contains)
+	},
+}
+
+func (t *PredicateOutputTransformer) getTagName() string {
+	if t.TagName == "" {
+		return PredicateDefaultTagName
+	}
+	return t.TagName
+}
+
+func (t *PredicateOutputTransformer) transformConditionPredicate(condition contract.FilterCondition) (Predicate, *contract.Error) {
+	resolver, isSupported := conditionResolversPredicate[condition.Operator]
+	if !isSupported {
+		return nil, contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("unsupported operator %s", condition.Operator))
+	}
+	matches := resolver(condition, t.CaseInsensitiveLike)
+	path, tagName := strings.Split(condition.Field, "."), t.getTagName()
+	return func(record any) (bool, error) {
+		recordValue, err := resolvePredicatePath(record, path, condition.Field, tagName)
+		if err != nil {
+			return false, err
+		}
+		return matches(recordValue)
+	}, nil
+}
+
+// transformFiltersPredicate returns nil for empty filters, the evaluation of a group stops at the first decisive condition
+func (t *PredicateOutputTransformer) transformFiltersPredicate(filters contract.Filters) (Predicate, *contract.Error) {
+	if filters.IsEmpty() {
+		return nil, nil
+	}
+	var predicates []Predicate
+	for _, filter := range filters.Conditions.Filters {
+		predicate, err := t.transformFiltersPredicate(filter)
+		if err != nil {
+			return nil, err
+		}
+		if predicate != nil {
+			predicates = append(predicates, predicate)
+		}
+	}
+	for _, condition := range filters.Conditions.Conditions {
+		if condition.Field == "" || condition.Operator == "" {
+			continue
+		}
+		predicate, err := t.transformConditionPredicate(condition)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	if len(predicates) == 0 {
+		return nil, nil
+	}
+	isOr := filters.Logic == contract.FilterLogicOr
+	return func(record any) (bool, error) {
+		for _, predicate := range predicates {
+			matches, err := predicate(record)
+			if err != nil {
+				return false, err
+			}
+			if matches == isOr {
+				return isOr, nil
+			}
+		}
+		return !isOr, nil
+	}, nil
+}
+
+type PredicateOutputTransformer struct {
+	// CaseInsensitiveLike makes begins, contains, not-contains, ends and match-phrase case-insensitive (like ILIKE)
+	CaseInsensitiveLike bool
+	// TagName is the struct tag used to match the fields of structs, defaults to PredicateDefaultTagName
+	TagName string
+}
+
+// Transform compiles the filters into a Predicate, empty filters match every record
+func (t *PredicateOutputTransformer) Transform(input contract.Filters) (*PredicateOutput, *contract.Error) {
+	predicate, transformErr := t.transformFiltersPredicate(input)
+	if transformErr != nil {
+		return nil, transformErr
+	}
+	if predicate == nil {
+		predicate = func(record any) (bool, error) {
+			return true, nil
+		}
+	}
+
+	output := PredicateOutput{filters: input}
+	err := output.SetData(predicate)
+	if err != nil {
+		return nil, contract.NewError(contract.NonWriteableOutputData, err.Error())
+	}
+	return &output, nil
+}
@@ -0,0 +1,327 @@
+package output
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testPredicateOrder struct {
+	Status    string    `json:"status"`
+	Total     int64     `json:"total"`
+	Paid      bool      `json:"paid"`
+	CreatedAt time.Time `json:"created_at"`
+	Note      *string   `json:"note"`
+}
+
+type testPredicateStatus string
+
+func newTestPredicateFilters(logic contract.FilterLogic, conditions ...contract.FilterCondition) contract.Filters {
+	return contract.Filters{Logic: logic, Conditions: contract.FilterConditions{Conditions: conditions}}
+}
+
+func TestPredicateOutputTransformer_Transform(t1 *testing.T) {
+	note := "Deliver ASAP"
+	order := testPredicateOrder{
+		Status:    "shipped",
+		Total:     120,
+		Paid:      true,
+		CreatedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Note:      &note,
+	}
+	tests := []struct {
+		name                string
+		input               contract.Filters
+		caseInsensitiveLike bool
+		record              any
+		want                bool
+		wantErr             bool
+	}{
+		{
+			name:   "empty filters",
+			input:  contract.Filters{},
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "equal",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorEqual, Value: "shipped"}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "equal is case-sensitive",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorEqual, Value: "Shipped"}),
+			record: order,
+			want:   false,
+		},
+		{
+			name:   "number from JSON",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 120.0}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "number from a string",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorLowerThan, Value: "99.5"}),
+			record: order,
+			want:   false,
+		},
+		{
+			name:   "boolean",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "paid", Operator: contract.FilterOperatorEqual, Value: true}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "time from a date",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "created_at", Operator: contract.FilterOperatorGreaterThan, Value: "2024-03-01"}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "time from RFC 3339",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "created_at", Operator: contract.FilterOperatorLowerThanOrEqual, Value: "2024-03-01T12:00:00Z"}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "begins",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorBegins, Value: "Deliver"}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "contains is case-sensitive",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorContains, Value: "asap"}),
+			record: order,
+			want:   false,
+		},
+		{
+			name:                "case-insensitive contains",
+			input:               newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorContains, Value: "asap"}),
+			caseInsensitiveLike: true,
+			record:              order,
+			want:                true,
+		},
+		{
+			name:   "ends",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorEnds, Value: "ASAP"}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "not contains",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorNotContains, Value: "ASAP"}),
+			record: order,
+			want:   false,
+		},
+		{
+			name:   "match phrase",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorMatchPhrase, Value: "ver AS"}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "in",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorIn, Value: []string{"new", "shipped"}}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "in numbers",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorIn, Value: []any{100.0, 120.0}}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "not in",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorNotIn, Value: []string{"new", "shipped"}}),
+			record: order,
+			want:   false,
+		},
+		{
+			name:   "nil",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorIsNil}),
+			record: testPredicateOrder{},
+			want:   true,
+		},
+		{
+			name:   "not null",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorIsNotNil}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "empty",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorIsEmpty}),
+			record: testPredicateOrder{},
+			want:   true,
+		},
+		{
+			name:   "not empty",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorIsNotEmpty}),
+			record: map[string]any{"status": ""},
+			want:   false,
+		},
+		{
+			name:   "empty named string type",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorIsEmpty}),
+			record: map[string]any{"status": testPredicateStatus("")},
+			want:   true,
+		},
+		{
+			name:   "not empty named string type",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorIsNotEmpty}),
+			record: map[string]any{"status": testPredicateStatus("new")},
+			want:   true,
+		},
+		{
+			name:   "empty doesn't match null like in SQL",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorIsEmpty}),
+			record: map[string]any{"status": nil},
+			want:   false,
+		},
+		{
+			name:   "not empty doesn't match null like in SQL",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "note", Operator: contract.FilterOperatorIsNotEmpty}),
+			record: testPredicateOrder{},
+			want:   false,
+		},
+		{
+			name:   "not equal doesn't match null like in SQL",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorNotEqual, Value: "new"}),
+			record: map[string]any{"status": nil},
+			want:   false,
+		},
+		{
+			name:   "not in doesn't match null like in SQL",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorNotIn, Value: []string{"new"}}),
+			record: map[string]any{},
+			want:   false,
+		},
+		{
+			name:   "in with null in the list",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorIn, Value: []any{1, nil, 2}}),
+			record: map[string]any{"total": 2},
+			want:   true,
+		},
+		{
+			name:   "not in with null in the list doesn't match like in SQL",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorNotIn, Value: []any{1, nil}}),
+			record: map[string]any{"total": 2},
+			want:   false,
+		},
+		{
+			name:   "greater than or equal or nil",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 500}),
+			record: map[string]any{"total": nil},
+			want:   true,
+		},
+		{
+			name:   "lower than or equal or nil",
+			input:  newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorLowerThanOrEqualOrNil, Value: 100}),
+			record: order,
+			want:   false,
+		},
+		{
+			name: "nested groups",
+			input: contract.Filters{
+				Logic: contract.FilterLogicOr,
+				Conditions: contract.FilterConditions{
+					Filters: []contract.Filters{
+						newTestPredicateFilters(contract.FilterLogicAnd,
+							contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorEqual, Value: "new"},
+							contract.FilterCondition{Field: "paid", Operator: contract.FilterOperatorEqual, Value: true},
+						),
+						newTestPredicateFilters(contract.FilterLogicAnd,
+							contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorEqual, Value: "shipped"},
+							contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorGreaterThan, Value: 100.0},
+						),
+					},
+				},
+			},
+			record: order,
+			want:   true,
+		},
+		{
+			name:   "or stops at the first match",
+			input:  newTestPredicateFilters(contract.FilterLogicOr, contract.FilterCondition{Field: "status", Operator: contract.FilterOperatorEqual, Value: "shipped"}, contract.FilterCondition{Field: "unknown", Operator: contract.FilterOperatorEqual, Value: "x"}),
+			record: order,
+			want:   true,
+		},
+		{
+			name:    "invalid input - unknown field",
+			input:   newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "unknown", Operator: contract.FilterOperatorEqual, Value: "x"}),
+			record:  order,
+			wantErr: true,
+		},
+		{
+			name:    "invalid input - not a number",
+			input:   newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "total", Operator: contract.FilterOperatorEqual, Value: "many"}),
+			record:  order,
+			wantErr: true,
+		},
+		{
+			name:    "invalid input - not a date",
+			input:   newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "created_at", Operator: contract.FilterOperatorEqual, Value: "yesterday"}),
+			record:  order,
+			wantErr: true,
+		},
+		{
+			name:    "invalid input - comparing a list",
+			input:   newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "tags", Operator: contract.FilterOperatorEqual, Value: "a"}),
+			record:  map[string]any{"tags": []any{"a"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := PredicateOutputTransformer{CaseInsensitiveLike: tt.caseInsensitiveLike}
+			output, transformErr := t.Transform(tt.input)
+			if transformErr != nil {
+				t1.Fatalf("Transform() error = %v", transformErr)
+			}
+			predicate, _ := output.GetData()
+			got, err := predicate(tt.record)
+			if (err != nil) != tt.wantErr {
+				t1.Errorf("predicate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t1.Errorf("predicate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPredicateOutputTransformer_TransformUnsupportedOperator(t1 *testing.T) {
+	t := PredicateOutputTransformer{}
+	got, err := t.Transform(newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "key", Operator: "unknown", Value: "val"}))
+	wantErr := contract.NewError(contract.NonWriteableOutputData, "unsupported operator unknown")
+	if !reflect.DeepEqual(err, wantErr) {
+		t1.Errorf("Transform() error = %v, wantErr %v", err, wantErr)
+	}
+	if got != nil {
+		t1.Errorf("Transform() got = %v, want nil", got)
+	}
+}
+
+func TestPredicateOutput_GetDataString(t1 *testing.T) {
+	t := PredicateOutputTransformer{}
+	output, _ := t.Transform(newTestPredicateFilters(contract.FilterLogicOr,
+		contract.FilterCondition{Field: "key", Operator: contract.FilterOperatorEqual, Value: "val"},
+		contract.FilterCondition{Field: "key2", Operator: contract.FilterOperatorIsNil},
+	))
+	got, err := output.GetDataString()
+	if err != nil {
+		t1.Fatalf("GetDataString() error = %v", err)
+	}
+	if want := `key = "val" OR key2 IS NULL`; got != want {
+		t1.Errorf("GetDataString() got = %v, want %v", got, want)
+	}
+	if _, err := output.GetDataJson(); err == nil {
+		t1.Errorf("GetDataJson() error = nil, want an error")
+	}
+}
@@ -0,0 +1,143 @@
+package output
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type predicateFieldError struct {
+	field   string
+	message string
+}
+
+func (e predicateFieldError) Error() string {
+	return fmt.Sprintf("field %q: %s", e.field, e.message)
+}
+
+// indirectValue dereferences pointers and interfaces, invalid is returned for nil
+func indirectValue(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+type predicateStructField struct {
+	name  string
+	index []int
+}
+
+type predicateStructFieldsKey struct {
+	structType reflect.Type
+	tagName    string
+}
+
+// predicateStructFieldsCache keeps the matchable fields of each struct type (per tag name), listing them using
+// reflection for every record would dominate the filtering of large slices
+var predicateStructFieldsCache sync.Map
+
+func predicateStructFields(structType reflect.Type, tagName string) []predicateStructField {
+	key := predicateStructFieldsKey{structType, tagName}
+	if fields, isCached := predicateStructFieldsCache.Load(key); isCached {
+		return fields.([]predicateStructField)
+	}
+	var fields []predicateStructField
+	for _, field := range reflect.VisibleFields(structType) {
+		isEmbeddedStruct := field.Anonymous && (field.Type.Kind() == reflect.Struct || field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct)
+		if !field.IsExported() || isEmbeddedStruct {
+			// the fields of embedded structs are visible on their own
+			continue
+		}
+		fieldName, _, _ := strings.Cut(field.Tag.Get(tagName), ",")
+		if fieldName == "-" {
+			continue
+		}
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+		fields = append(fields, predicateStructField{fieldName, field.Index})
+	}
+	cached, _ := predicateStructFieldsCache.LoadOrStore(key, fields)
+	return cached.([]predicateStructField)
+}
+
+// structFieldByName matches the name from the tag (or the field name if there is no tag) and falls back to a case-insensitive
+// match like encoding/json does, fields tagged with `-` and unexported fields are never matched
+func structFieldByName(structType reflect.Type, tagName string, name string) ([]int, bool) {
+	var caseInsensitiveMatch []int
+	for _, field := range predicateStructFields(structType, tagName) {
+		if field.name == name {
+			return field.index, true
+		}
+		if caseInsensitiveMatch == nil && strings.EqualFold(field.name, name) {
+			caseInsensitiveMatch = field.index
+		}
+	}
+	return caseInsensitiveMatch, caseInsensitiveMatch != nil
+}
+
+// resolvePredicatePath walks the dotted path through maps (string keys), structs and slices (numeric segments);
+// nil pointers and missing map keys resolve to nil, unknown struct fields are an error (like an unknown column in SQL)
+func resolvePredicatePath(record any, path []string, field string, tagName string) (any, error) {
+	value := reflect.ValueOf(record)
+	for _, segment := range path {
+		value = indirectValue(value)
+		if !value.IsValid() {
+			return nil, nil
+		}
+		switch value.Kind() {
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return nil, predicateFieldError{field, fmt.Sprintf("can't read %q from %s", segment, value.Type())}
+			}
+			value = value.MapIndex(reflect.ValueOf(segment).Convert(value.Type().Key()))
+		case reflect.Struct:
+			index, found := structFieldByName(value.Type(), tagName, segment)
+			if !found {
+				return nil, predicateFieldError{field, fmt.Sprintf("%s has no field %q", value.Type(), segment)}
+			}
+			var err error
+			value, err = value.FieldByIndexErr(index)
+			if err != nil {
+				// nil embedded pointer
+				return nil, nil
+			}
+		case reflect.Slice, reflect.Array:
+			position, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil, predicateFieldError{field, fmt.Sprintf("expected an index instead of %q", segment)}
+			}
+			if position < 0 || position >= value.Len() {
+				return nil, nil
+			}
+			value = value.Index(position)
+		default:
+			return nil, predicateFieldError{field, fmt.Sprintf("can't read %q from %s", segment, value.Type())}
+		}
+	}
+	return predicateValue(value)
+}
+
+// predicateValue unwraps the value, driver.Valuer (e.g. sql.NullString) is used the same way the database driver would
+func predicateValue(value reflect.Value) (any, error) {
+	value = indirectValue(value)
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if valuer, isValuer := value.Interface().(driver.Valuer); isValuer {
+		return valuer.Value()
+	}
+	if value.CanAddr() {
+		if valuer, isValuer := value.Addr().Interface().(driver.Valuer); isValuer {
+			return valuer.Value()
+		}
+	}
+	return value.Interface(), nil
+}
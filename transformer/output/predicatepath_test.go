@@ -0,0 +1,84 @@
+package output
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testPredicateBase struct {
+	ID int `json:"id"`
+}
+
+type testPredicateAddress struct {
+	City string
+}
+
+type testPredicateRecord struct {
+	testPredicateBase
+	Name      string `json:"name,omitempty"`
+	Title     string `json:"-"`
+	CreatedAt string `json:"created_at" db:"created"`
+	Address   *testPredicateAddress
+	Nickname  sql.NullString
+	Tags      []string
+	secret    string
+}
+
+func Test_resolvePredicatePath(t *testing.T) {
+	record := testPredicateRecord{
+		testPredicateBase: testPredicateBase{ID: 7},
+		Name:              "Jo",
+		Title:             "Dr",
+		CreatedAt:         "2024-01-01",
+		Address:           &testPredicateAddress{City: "Prague"},
+		Nickname:          sql.NullString{String: "J", Valid: true},
+		Tags:              []string{"a", "b"},
+		secret:            "x",
+	}
+	tests := []struct {
+		name    string
+		record  any
+		field   string
+		tagName string
+		want    any
+		wantErr bool
+	}{
+		{name: "map", record: map[string]any{"key": "val"}, field: "key", want: "val"},
+		{name: "missing key", record: map[string]any{"key": "val"}, field: "key2", want: nil},
+		{name: "nested map", record: map[string]any{"user": map[string]any{"name": "Jo"}}, field: "user.name", want: "Jo"},
+		{name: "nil record", record: nil, field: "key", want: nil},
+		{name: "tag", record: record, field: "name", want: "Jo"},
+		{name: "tag from another tag name", record: &record, field: "created", tagName: "db", want: "2024-01-01"},
+		{name: "field name", record: record, field: "Address.City", want: "Prague"},
+		{name: "case-insensitive field name", record: record, field: "address.city", want: "Prague"},
+		{name: "embedded struct", record: record, field: "id", want: 7},
+		{name: "nil pointer", record: testPredicateRecord{}, field: "Address.City", want: nil},
+		{name: "driver valuer", record: record, field: "Nickname", want: "J"},
+		{name: "invalid driver valuer", record: testPredicateRecord{}, field: "Nickname", want: nil},
+		{name: "slice index", record: record, field: "Tags.1", want: "b"},
+		{name: "slice index out of range", record: record, field: "Tags.2", want: nil},
+		{name: "ignored field", record: record, field: "Title", wantErr: true},
+		{name: "unexported field", record: record, field: "secret", wantErr: true},
+		{name: "unknown field", record: record, field: "unknown", wantErr: true},
+		{name: "slice without index", record: record, field: "Tags.name", wantErr: true},
+		{name: "scalar", record: record, field: "name.first", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagName := tt.tagName
+			if tagName == "" {
+				tagName = PredicateDefaultTagName
+			}
+			got, err := resolvePredicatePath(tt.record, strings.Split(tt.field, "."), tt.field, tagName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolvePredicatePath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolvePredicatePath() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+)
+
+func newFilterPredicate(filters contract.Filters) (Predicate, error) {
+	t := PredicateOutputTransformer{}
+	output, transformErr := t.Transform(filters)
+	if transformErr != nil {
+		return nil, fmt.Errorf("%w: %v", transformErr.Err, transformErr.Payload)
+	}
+	return output.GetData()
+}
+
+// FilterSlice returns the items matching the filters (see PredicateOutputTransformer), the order of the items is kept
+func FilterSlice[T any](items []T, filters contract.Filters) ([]T, error) {
+	predicate, err := newFilterPredicate(filters)
+	if err != nil {
+		return nil, err
+	}
+	var matchingItems []T
+	for _, item := range items {
+		matches, err := predicate(item)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			matchingItems = append(matchingItems, item)
+		}
+	}
+	return matchingItems, nil
+}
+
+// FilterSeq lazily yields the items matching the filters, an error is yielded (with the zero value) as the last pair
+func FilterSeq[T any](items iter.Seq[T], filters contract.Filters) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		predicate, err := newFilterPredicate(filters)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for item := range items {
+			matches, err := predicate(item)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if matches && !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
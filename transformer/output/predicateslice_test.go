@@ -0,0 +1,140 @@
+package output
+
+import (
+	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type testPredicateProduct struct {
+	Name  string   `json:"product_name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+var testPredicateProducts = []testPredicateProduct{
+	{Name: "Lamp", Price: 25},
+	{Name: "Desk", Price: 250},
+	{Name: "Desk lamp", Price: 40},
+	{Name: "Chair", Price: 120},
+}
+
+func TestFilterSlice(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters contract.Filters
+		want    []testPredicateProduct
+		wantErr bool
+	}{
+		{
+			name:    "empty filters",
+			filters: contract.Filters{},
+			want:    testPredicateProducts,
+			wantErr: false,
+		},
+		{
+			name: "json tags",
+			filters: newTestPredicateFilters(contract.FilterLogicOr,
+				contract.FilterCondition{Field: "product_name", Operator: contract.FilterOperatorEnds, Value: "lamp"},
+				contract.FilterCondition{Field: "price", Operator: contract.FilterOperatorGreaterThanOrEqual, Value: 200.0},
+			),
+			want:    []testPredicateProduct{testPredicateProducts[1], testPredicateProducts[2]},
+			wantErr: false,
+		},
+		{
+			name:    "no match",
+			filters: newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "price", Operator: contract.FilterOperatorLowerThan, Value: 10.0}),
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "invalid input - field name instead of the tag",
+			filters: newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "Name", Operator: contract.FilterOperatorEqual, Value: "Lamp"}),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid input - unsupported operator",
+			filters: newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "price", Operator: "unknown", Value: 10.0}),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterSlice(testPredicateProducts, tt.filters)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FilterSlice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterSlice() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSlice_Pointers(t *testing.T) {
+	items := []*testPredicateProduct{&testPredicateProducts[0], nil, &testPredicateProducts[3]}
+	got, err := FilterSlice(items, newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "price", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 100}))
+	if err != nil {
+		t.Fatalf("FilterSlice() error = %v", err)
+	}
+	if want := []*testPredicateProduct{nil, &testPredicateProducts[3]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterSlice() got = %v, want %v", got, want)
+	}
+}
+
+func TestFilterSeq(t *testing.T) {
+	filters := newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "product_name", Operator: contract.FilterOperatorContains, Value: "a"})
+	var got []string
+	for product, err := range FilterSeq(slices.Values(testPredicateProducts), filters) {
+		if err != nil {
+			t.Fatalf("FilterSeq() error = %v", err)
+		}
+		got = append(got, product.Name)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if want := []string{"Lamp", "Desk lamp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterSeq() got = %v, want %v", got, want)
+	}
+}
+
+func TestFilterSeq_Error(t *testing.T) {
+	records := []map[string]any{{"price": 10.0}, {"price": []any{20.0}}, {"price": 30.0}}
+	filters := newTestPredicateFilters(contract.FilterLogicAnd, contract.FilterCondition{Field: "price", Operator: contract.FilterOperatorGreaterThan, Value: 5.0})
+	var got []map[string]any
+	var gotErr error
+	for record, err := range FilterSeq(slices.Values(records), filters) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		got = append(got, record)
+	}
+	if gotErr == nil {
+		t.Errorf("FilterSeq() error = nil, want an error")
+	}
+	if want := records[:1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterSeq() got = %v, want %v", got, want)
+	}
+}
+
+func Test_predicateStructFields(t *testing.T) {
+	productType := reflect.TypeOf(testPredicateProduct{})
+	want := []predicateStructField{{"product_name", []int{0}}, {"price", []int{1}}, {"tags", []int{2}}}
+	if got := predicateStructFields(productType, "json"); !reflect.DeepEqual(got, want) {
+		t.Errorf("predicateStructFields() got = %v, want %v", got, want)
+	}
+	cached, isCached := predicateStructFieldsCache.Load(predicateStructFieldsKey{productType, "json"})
+	if !isCached || !reflect.DeepEqual(cached, want) {
+		t.Errorf("predicateStructFields() cached = %v, want %v", cached, want)
+	}
+	wantByName := []predicateStructField{{"Name", []int{0}}, {"Price", []int{1}}, {"Tags", []int{2}}}
+	if got := predicateStructFields(productType, "db"); !reflect.DeepEqual(got, wantByName) {
+		t.Errorf("predicateStructFields() got = %v, want %v", got, wantByName)
+	}
+}
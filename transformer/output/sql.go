@@ -1,7 +1,9 @@
 package output
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
 	"log"
@@ -9,6 +11,9 @@ import (
 	"strings"
 )
 
+// sqlIdentifierPattern only allows plain identifiers and dotted paths of them (e.g. `table.column`)
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
 type SQLTuple struct {
 	Query  string `json:"query"`
 	Params []any  `json:"params"`
@@ -16,6 +21,9 @@ type SQLTuple struct {
 
 type SQLOutput struct {
 	contract.InputOutputType[SQLTuple]
+	dialect     SQLDialectInterface
+	paramPrefix string
+	paramOffset int
 }
 
 func (o *SQLOutput) GetDataJson() ([]byte, error) {
@@ -33,156 +41,273 @@ func (o *SQLOutput) GetDataJson() ([]byte, error) {
 	return jsonData, nil
 }
 
+// GetNamedParams returns the params keyed by the names of the placeholders (e.g. for sqlx.NamedExec)
+func (o *SQLOutput) GetNamedParams() (map[string]any, error) {
+	rawData, err := o.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if o.paramPrefix == "" {
+		return nil, errNoParamPrefix
+	}
+	namedParams := make(map[string]any, len(rawData.Params))
+	for index, param := range rawData.Params {
+		namedParams[sqlParamName(o.paramPrefix, o.paramOffset+index+1)] = param
+	}
+	return namedParams, nil
+}
+
+// GetNamedArgs returns the params as sql.NamedArg in the order of the placeholders (for drivers supporting named params)
+func (o *SQLOutput) GetNamedArgs() ([]sql.NamedArg, error) {
+	rawData, err := o.GetData()
+	if err != nil {
+		return nil, err
+	}
+	if o.paramPrefix == "" {
+		return nil, errNoParamPrefix
+	}
+	namedArgs := make([]sql.NamedArg, 0, len(rawData.Params))
+	for index, param := range rawData.Params {
+		namedArgs = append(namedArgs, sql.Named(sqlParamName(o.paramPrefix, o.paramOffset+index+1), param))
+	}
+	return namedArgs, nil
+}
+
 func (o *SQLOutput) GetDataString() (string, error) {
 	log.Printf("A call to GetDataString on SQLOutput detected - this is not safe! Only meant for debugging purposes!")
 	rawData, err := o.GetData()
 	if err != nil {
 		return "", err
 	}
-	template := regexp.MustCompile(`\$([0-9]+)`).ReplaceAllString(rawData.Query, "'%[$1]v'")
-	return fmt.Sprintf(template, rawData.Params...), nil
+	dialect := o.dialect
+	if dialect == nil {
+		dialect = SQLDialectPostgres{}
+	}
+	// placeholders appear in the order of the params, going from the last one makes sure that `$1` doesn't match `$10`
+	// and that placeholder-like text in the already interpolated values is skipped
+	query := rawData.Query
+	end := len(query)
+	for index := len(rawData.Params); index > 0; index-- {
+		placeholder := sqlPlaceholder(dialect, o.paramPrefix, o.paramOffset+index)
+		position := strings.LastIndex(query[:end], placeholder)
+		if position < 0 {
+			continue
+		}
+		query = fmt.Sprintf("%s'%v'%s", query[:position], rawData.Params[index-1], query[position+len(placeholder):])
+		end = position
+	}
+	return query, nil
 }
 
 type SQLOutputTransformer struct {
+	// Dialect defaults to SQLDialectPostgres
+	Dialect SQLDialectInterface
+	// ParamPrefix switches to named placeholders called `<ParamPrefix><position>` (e.g. `:p1` for Oracle or `@p1` for Postgres)
+	ParamPrefix string
+	// ParamOffset is added to the positions of the placeholders (e.g. 2 makes the first placeholder `$3`),
+	// so that the query can be embedded in a query that already has params
+	ParamOffset int
+	// CaseInsensitiveLike makes begins/contains/not-contains/ends/match-phrase case-insensitive if supported by the dialect
+	CaseInsensitiveLike bool
+	// InlineBooleans writes booleans into the query using the literals of the dialect (e.g. `TRUE` or `1`) instead of binding them as params
+	InlineBooleans bool
 }
 
-func addToParams(params *[]any, value any) int {
-	*params = append(*params, value)
-	return len(*params)
+// sqlContext carries the options of the transformer and the collected params through the transformation
+type sqlContext struct {
+	dialect             SQLDialectInterface
+	caseInsensitiveLike bool
+	inlineBooleans      bool
+	paramPrefix         string
+	paramOffset         int
+	params              *[]any
 }
 
-var conditionResolversSQL = map[contract.FilterOperator]func(contract.FilterCondition, *[]any) string{
-	contract.FilterOperatorEqual: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("%s = $%d", condition.Field, index)
+var errNoParamPrefix = errors.New("named params require SQLOutputTransformer.ParamPrefix to be set")
+
+func sqlParamName(paramPrefix string, position int) string {
+	return fmt.Sprintf("%s%d", paramPrefix, position)
+}
+
+func sqlPlaceholder(dialect SQLDialectInterface, paramPrefix string, position int) string {
+	if paramPrefix != "" {
+		return dialect.NamedPlaceholder(sqlParamName(paramPrefix, position))
+	}
+	return dialect.Placeholder(position)
+}
+
+func (c *sqlContext) field(condition contract.FilterCondition) string {
+	return c.dialect.QuoteIdentifier(condition.Field)
+}
+
+// param adds the value to the params and returns its placeholder, booleans are inlined as literals if requested
+func (c *sqlContext) param(value any) string {
+	if boolean, isBool := value.(bool); isBool && c.inlineBooleans {
+		return c.dialect.BooleanLiteral(boolean)
+	}
+	*c.params = append(*c.params, value)
+	return sqlPlaceholder(c.dialect, c.paramPrefix, c.paramOffset+len(*c.params))
+}
+
+func (c *sqlContext) like(condition contract.FilterCondition, leadingWildcard bool, trailingWildcard bool, negated bool) string {
+	pattern := c.dialect.LikePattern(fmt.Sprint(condition.Value), leadingWildcard, trailingWildcard, c.caseInsensitiveLike)
+	return c.dialect.Like(c.field(condition), c.param(pattern), negated, c.caseInsensitiveLike)
+}
+
+// list adds each item of the value to the params (a single value is treated as a list of one item)
+func (c *sqlContext) list(condition contract.FilterCondition) string {
+	var placeholders []string
+	for _, value := range condition.ValueList() {
+		placeholders = append(placeholders, c.param(value))
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+var conditionResolversSQL = map[contract.FilterOperator]func(contract.FilterCondition, *sqlContext) string{
+	contract.FilterOperatorEqual: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s = %s", context.field(condition), context.param(condition.Value))
 	},
-	contract.FilterOperatorNotEqual: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("%s != $%d", condition.Field, index)
+	contract.FilterOperatorNotEqual: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s != %s", context.field(condition), context.param(condition.Value))
 	},
-	contract.FilterOperatorGreaterThan: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("%s > $%d", condition.Field, index)
+	contract.FilterOperatorGreaterThan: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s > %s", context.field(condition), context.param(condition.Value))
 	},
-	contract.FilterOperatorGreaterThanOrEqual: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("%s >= $%d", condition.Field, index)
+	contract.FilterOperatorGreaterThanOrEqual: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s >= %s", context.field(condition), context.param(condition.Value))
 	},
-	contract.FilterOperatorGreaterThanOrEqualOrNil: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("(%s >= $%d OR %s IS NULL)", condition.Field, index, condition.Field)
+	contract.FilterOperatorGreaterThanOrEqualOrNil: func(condition contract.FilterCondition, context *sqlContext) string {
+		field := context.field(condition)
+		return fmt.Sprintf("(%s >= %s OR %s IS NULL)", field, context.param(condition.Value), field)
 	},
-	contract.FilterOperatorLowerThan: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("%s < $%d", condition.Field, index)
+	contract.FilterOperatorLowerThan: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s < %s", context.field(condition), context.param(condition.Value))
 	},
-	contract.FilterOperatorLowerThanOrEqual: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("%s <= $%d", condition.Field, index)
+	contract.FilterOperatorLowerThanOrEqual: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s <= %s", context.field(condition), context.param(condition.Value))
 	},
-	contract.FilterOperatorLowerThanOrEqualOrNil: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, condition.Value)
-		return fmt.Sprintf("(%s <= $%d OR %s IS NULL)", condition.Field, index, condition.Field)
+	contract.FilterOperatorLowerThanOrEqualOrNil: func(condition contract.FilterCondition, context *sqlContext) string {
+		field := context.field(condition)
+		return fmt.Sprintf("(%s <= %s OR %s IS NULL)", field, context.param(condition.Value), field)
 	},
-	contract.FilterOperatorBegins: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, fmt.Sprintf("%s%%", condition.Value))
-		return fmt.Sprintf("%s LIKE $%d", condition.Field, index)
+	contract.FilterOperatorBegins: func(condition contract.FilterCondition, context *sqlContext) string {
+		return context.like(condition, false, true, false)
 	},
-	contract.FilterOperatorContains: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, fmt.Sprintf("%%%s%%", condition.Value))
-		return fmt.Sprintf("%s LIKE $%d", condition.Field, index)
+	contract.FilterOperatorContains: func(condition contract.FilterCondition, context *sqlContext) string {
+		return context.like(condition, true, true, false)
 	},
-	contract.FilterOperatorNotContains: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, fmt.Sprintf("%%%s%%", condition.Value))
-		return fmt.Sprintf("%s NOT LIKE $%d", condition.Field, index)
+	contract.FilterOperatorNotContains: func(condition contract.FilterCondition, context *sqlContext) string {
+		return context.like(condition, true, true, true)
 	},
-	contract.FilterOperatorEnds: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, fmt.Sprintf("%%%s", condition.Value))
-		return fmt.Sprintf("%s LIKE $%d", condition.Field, index)
+	contract.FilterOperatorEnds: func(condition contract.FilterCondition, context *sqlContext) string {
+		return context.like(condition, true, false, false)
 	},
-	contract.FilterOperatorIsNil: func(condition contract.FilterCondition, params *[]any) string {
-		return fmt.Sprintf("%s IS NULL", condition.Field)
+	contract.FilterOperatorIsNil: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s IS NULL", context.field(condition))
 	},
-	contract.FilterOperatorIsNotNil: func(condition contract.FilterCondition, params *[]any) string {
-		return fmt.Sprintf("%s IS NOT NULL", condition.Field)
+	contract.FilterOperatorIsNotNil: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s IS NOT NULL", context.field(condition))
 	},
-	contract.FilterOperatorIsEmpty: func(condition contract.FilterCondition, params *[]any) string {
-		return fmt.Sprintf("%s = ''", condition.Field)
+	contract.FilterOperatorIsEmpty: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s = ''", context.field(condition))
 	},
-	contract.FilterOperatorIsNotEmpty: func(condition contract.FilterCondition, params *[]any) string {
-		return fmt.Sprintf("%s != ''", condition.Field)
+	contract.FilterOperatorIsNotEmpty: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s != ''", context.field(condition))
 	},
-	contract.FilterOperatorIn: func(condition contract.FilterCondition, params *[]any) string {
-		var indices []string
-		for _, value := range condition.Value.([]any) {
-			index := addToParams(params, value)
-			indices = append(indices, fmt.Sprintf("$%d", index))
-		}
-		return fmt.Sprintf("%s IN (%s)", condition.Field, strings.Join(indices, ", "))
+	contract.FilterOperatorIn: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s IN (%s)", context.field(condition), context.list(condition))
 	},
-	contract.FilterOperatorNotIn: func(condition contract.FilterCondition, params *[]any) string {
-		var indices []string
-		for _, value := range condition.Value.([]any) {
-			index := addToParams(params, value)
-			indices = append(indices, fmt.Sprintf("$%d", index))
-		}
-		return fmt.Sprintf("%s NOT IN (%s)", condition.Field, strings.Join(indices, ", "))
+	contract.FilterOperatorNotIn: func(condition contract.FilterCondition, context *sqlContext) string {
+		return fmt.Sprintf("%s NOT IN (%s)", context.field(condition), context.list(condition))
 	},
-	contract.FilterOperatorMatchPhrase: func(condition contract.FilterCondition, params *[]any) string {
-		index := addToParams(params, fmt.Sprintf("%%%s%%", condition.Value))
-		return fmt.Sprintf("%s LIKE $%d", condition.Field, index)
+	contract.FilterOperatorMatchPhrase: func(condition contract.FilterCondition, context *sqlContext) string {
+		return context.like(condition, true, true, false)
 	},
 }
 
-func transformConditionSQL(condition contract.FilterCondition, outputConditions *[]string, params *[]any) {
+// transformConditionSQL rejects fields that aren't plain identifiers so that nothing but the quoted identifier gets into the query
+func transformConditionSQL(condition contract.FilterCondition, outputConditions *[]string, context *sqlContext) *contract.Error {
 	if condition.Field == "" || condition.Operator == "" {
-		return
+		return nil
+	}
+	if !sqlIdentifierPattern.MatchString(condition.Field) {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("invalid field %q", condition.Field))
 	}
-	outputCondition := conditionResolversSQL[condition.Operator](condition, params)
-	*outputConditions = append(*outputConditions, outputCondition)
+	resolver, isSupported := conditionResolversSQL[condition.Operator]
+	if !isSupported {
+		return contract.NewError(contract.NonWriteableOutputData, fmt.Sprintf("unsupported operator %s", condition.Operator))
+	}
+	*outputConditions = append(*outputConditions, resolver(condition, context))
+	return nil
 }
 
-func transformConditionsSQL(conditions contract.FilterConditions, params *[]any) []string {
+func transformConditionsSQL(conditions contract.FilterConditions, context *sqlContext) ([]string, *contract.Error) {
 	if conditions.IsEmpty() {
-		return nil
+		return nil, nil
 	}
 	var outputConditions []string
 	if conditions.Filters != nil {
 		for _, filter := range conditions.Filters {
 			var condition string
-			transformFiltersSQL(filter, &condition, params)
+			if err := transformFiltersSQL(filter, &condition, context); err != nil {
+				return nil, err
+			}
 			outputConditions = append(outputConditions, condition)
 		}
 	}
 	if conditions.Conditions != nil {
 		for _, condition := range conditions.Conditions {
-			transformConditionSQL(condition, &outputConditions, params)
+			if err := transformConditionSQL(condition, &outputConditions, context); err != nil {
+				return nil, err
+			}
 		}
 	}
-	return outputConditions
+	return outputConditions, nil
 }
 
-func transformFiltersSQL(filters contract.Filters, target *string, params *[]any) {
+func transformFiltersSQL(filters contract.Filters, target *string, context *sqlContext) *contract.Error {
 	if filters.IsEmpty() {
-		return
+		return nil
+	}
+	conditions, err := transformConditionsSQL(filters.Conditions, context)
+	if err != nil {
+		return err
 	}
-	conditions := transformConditionsSQL(filters.Conditions, params)
 	if len(conditions) == 0 {
-		return
+		return nil
 	}
 	if len(conditions) == 1 {
 		*target = conditions[0]
-		return
+		return nil
 	}
 	*target = fmt.Sprintf("(%s)", strings.Join(conditions, fmt.Sprintf(" %s ", strings.ToUpper(string(filters.Logic)))))
+	return nil
+}
+
+func (t *SQLOutputTransformer) getDialect() SQLDialectInterface {
+	if t.Dialect == nil {
+		return SQLDialectPostgres{}
+	}
+	return t.Dialect
 }
 
 func (t *SQLOutputTransformer) Transform(input contract.Filters) (*SQLOutput, *contract.Error) {
 	var sql string
 	var params []any
-	transformFiltersSQL(input, &sql, &params)
+	transformErr := transformFiltersSQL(input, &sql, &sqlContext{
+		dialect:             t.getDialect(),
+		caseInsensitiveLike: t.CaseInsensitiveLike,
+		inlineBooleans:      t.InlineBooleans,
+		paramPrefix:         t.ParamPrefix,
+		paramOffset:         t.ParamOffset,
+		params:              &params,
+	})
+	if transformErr != nil {
+		return nil, transformErr
+	}
 
-	var output SQLOutput
+	// the default dialect is not stored so that the output equals the one created using contract.NewInputOutputType
+	output := SQLOutput{dialect: t.Dialect, paramPrefix: t.ParamPrefix, paramOffset: t.ParamOffset}
 	if sql == "" {
 		return &output, nil
 	}
@@ -1,29 +1,32 @@
 package output
 
 import (
+	"database/sql"
+	"fmt"
 	"github.com/wernerdweight/filter-transformer-go/transformer/contract"
 	"reflect"
+	"strings"
 	"testing"
 )
 
 var testOutputSQL0, _ = contract.NewInputOutputType(SQLTuple{
-	Query:  "key = $1",
+	Query:  `"key" = $1`,
 	Params: []any{"val"},
 }, &SQLOutput{})
 var testOutputSQL1, _ = contract.NewInputOutputType(SQLTuple{
-	Query:  "(key = $1 OR key2 != $2)",
+	Query:  `("key" = $1 OR "key2" != $2)`,
 	Params: []any{"val", "val2"},
 }, &SQLOutput{})
 var testOutputSQL2, _ = contract.NewInputOutputType(SQLTuple{
-	Query:  "key IS NOT NULL",
+	Query:  `"key" IS NOT NULL`,
 	Params: nil,
 }, &SQLOutput{})
 var testOutputSQL3, _ = contract.NewInputOutputType(SQLTuple{
-	Query:  "key >= $1",
+	Query:  `"key" >= $1`,
 	Params: []any{123},
 }, &SQLOutput{})
 var testOutputSQL4, _ = contract.NewInputOutputType(SQLTuple{
-	Query:  "((key = $1 AND key2 != '') OR (key3 LIKE $2 AND key4 > $3))",
+	Query:  `(("key" = $1 AND "key2" != '') OR ("key3" LIKE $2 AND "key4" > $3))`,
 	Params: []any{"val", "%val3%", 123},
 }, &SQLOutput{})
 
@@ -212,31 +215,31 @@ func TestSQLOutput_GetDataJson(t *testing.T) {
 		{
 			name:          "with data",
 			elasticOutput: *testOutputSQL0,
-			want:          []byte(`{"query":"key = $1","params":["val"]}`),
+			want:          []byte(`{"query":"\"key\" = $1","params":["val"]}`),
 			wantErr:       false,
 		},
 		{
 			name:          "with nested data",
 			elasticOutput: *testOutputSQL1,
-			want:          []byte(`{"query":"(key = $1 OR key2 != $2)","params":["val","val2"]}`),
+			want:          []byte(`{"query":"(\"key\" = $1 OR \"key2\" != $2)","params":["val","val2"]}`),
 			wantErr:       false,
 		},
 		{
 			name:          "with null value",
 			elasticOutput: *testOutputSQL2,
-			want:          []byte(`{"query":"key IS NOT NULL","params":null}`),
+			want:          []byte(`{"query":"\"key\" IS NOT NULL","params":null}`),
 			wantErr:       false,
 		},
 		{
 			name:          "with number value",
 			elasticOutput: *testOutputSQL3,
-			want:          []byte(`{"query":"key \u003e= $1","params":[123]}`),
+			want:          []byte(`{"query":"\"key\" \u003e= $1","params":[123]}`),
 			wantErr:       false,
 		},
 		{
 			name:          "with complex data",
 			elasticOutput: *testOutputSQL4,
-			want:          []byte(`{"query":"((key = $1 AND key2 != '') OR (key3 LIKE $2 AND key4 \u003e $3))","params":["val","%val3%",123]}`),
+			want:          []byte(`{"query":"((\"key\" = $1 AND \"key2\" != '') OR (\"key3\" LIKE $2 AND \"key4\" \u003e $3))","params":["val","%val3%",123]}`),
 			wantErr:       false,
 		},
 	}
@@ -270,31 +273,31 @@ func TestSQLOutput_GetDataString(t *testing.T) {
 		{
 			name:          "with data",
 			elasticOutput: *testOutputSQL0,
-			want:          `key = 'val'`,
+			want:          `"key" = 'val'`,
 			wantErr:       false,
 		},
 		{
 			name:          "with nested data",
 			elasticOutput: *testOutputSQL1,
-			want:          `(key = 'val' OR key2 != 'val2')`,
+			want:          `("key" = 'val' OR "key2" != 'val2')`,
 			wantErr:       false,
 		},
 		{
 			name:          "with null value",
 			elasticOutput: *testOutputSQL2,
-			want:          `key IS NOT NULL`,
+			want:          `"key" IS NOT NULL`,
 			wantErr:       false,
 		},
 		{
 			name:          "with number value",
 			elasticOutput: *testOutputSQL3,
-			want:          `key >= '123'`,
+			want:          `"key" >= '123'`,
 			wantErr:       false,
 		},
 		{
 			name:          "with complex data",
 			elasticOutput: *testOutputSQL4,
-			want:          `((key = 'val' AND key2 != '') OR (key3 LIKE '%val3%' AND key4 > '123'))`,
+			want:          `(("key" = 'val' AND "key2" != '') OR ("key3" LIKE '%val3%' AND "key4" > '123'))`,
 			wantErr:       false,
 		},
 	}
@@ -336,7 +339,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key = $1",
+				`"key" = $1`,
 			},
 			wantParams: &[]any{
 				"val",
@@ -354,7 +357,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key = $1",
+				`"key" = $1`,
 			},
 			wantParams: &[]any{
 				123,
@@ -372,7 +375,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key != $1",
+				`"key" != $1`,
 			},
 			wantParams: &[]any{
 				"val",
@@ -390,7 +393,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key != $1",
+				`"key" != $1`,
 			},
 			wantParams: &[]any{
 				123,
@@ -408,7 +411,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key > $1",
+				`"key" > $1`,
 			},
 			wantParams: &[]any{
 				123,
@@ -426,7 +429,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key > $1",
+				`"key" > $1`,
 			},
 			wantParams: &[]any{
 				"2021-01-01",
@@ -444,7 +447,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key >= $1",
+				`"key" >= $1`,
 			},
 			wantParams: &[]any{
 				123,
@@ -462,7 +465,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key >= $1",
+				`"key" >= $1`,
 			},
 			wantParams: &[]any{
 				"2021-01-01",
@@ -480,7 +483,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"(key >= $1 OR key IS NULL)",
+				`("key" >= $1 OR "key" IS NULL)`,
 			},
 			wantParams: &[]any{
 				123,
@@ -498,7 +501,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"(key >= $1 OR key IS NULL)",
+				`("key" >= $1 OR "key" IS NULL)`,
 			},
 			wantParams: &[]any{
 				"2021-01-01",
@@ -516,7 +519,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key < $1",
+				`"key" < $1`,
 			},
 			wantParams: &[]any{
 				123,
@@ -534,7 +537,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key < $1",
+				`"key" < $1`,
 			},
 			wantParams: &[]any{
 				"2021-01-01",
@@ -552,7 +555,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key <= $1",
+				`"key" <= $1`,
 			},
 			wantParams: &[]any{
 				123,
@@ -570,7 +573,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key <= $1",
+				`"key" <= $1`,
 			},
 			wantParams: &[]any{
 				"2021-01-01",
@@ -588,7 +591,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"(key <= $1 OR key IS NULL)",
+				`("key" <= $1 OR "key" IS NULL)`,
 			},
 			wantParams: &[]any{
 				123,
@@ -606,7 +609,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"(key <= $1 OR key IS NULL)",
+				`("key" <= $1 OR "key" IS NULL)`,
 			},
 			wantParams: &[]any{
 				"2021-01-01",
@@ -624,7 +627,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key LIKE $1",
+				`"key" LIKE $1`,
 			},
 			wantParams: &[]any{
 				"val%",
@@ -642,7 +645,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key LIKE $1",
+				`"key" LIKE $1`,
 			},
 			wantParams: &[]any{
 				"%val%",
@@ -660,7 +663,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key NOT LIKE $1",
+				`"key" NOT LIKE $1`,
 			},
 			wantParams: &[]any{
 				"%val%",
@@ -678,7 +681,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key LIKE $1",
+				`"key" LIKE $1`,
 			},
 			wantParams: &[]any{
 				"%val",
@@ -696,7 +699,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key IS NULL",
+				`"key" IS NULL`,
 			},
 			wantParams: &[]any{},
 		},
@@ -712,7 +715,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key IS NOT NULL",
+				`"key" IS NOT NULL`,
 			},
 			wantParams: &[]any{},
 		},
@@ -728,7 +731,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key = ''",
+				`"key" = ''`,
 			},
 			wantParams: &[]any{},
 		},
@@ -744,7 +747,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key != ''",
+				`"key" != ''`,
 			},
 			wantParams: &[]any{},
 		},
@@ -760,7 +763,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key IN ($1, $2)",
+				`"key" IN ($1, $2)`,
 			},
 			wantParams: &[]any{
 				"val1", "val2",
@@ -778,7 +781,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key IN ($1, $2)",
+				`"key" IN ($1, $2)`,
 			},
 			wantParams: &[]any{
 				123, 456,
@@ -796,7 +799,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key NOT IN ($1, $2)",
+				`"key" NOT IN ($1, $2)`,
 			},
 			wantParams: &[]any{
 				"val1", "val2",
@@ -814,7 +817,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key NOT IN ($1, $2)",
+				`"key" NOT IN ($1, $2)`,
 			},
 			wantParams: &[]any{
 				123, 456,
@@ -832,7 +835,7 @@ func Test_transformConditionSQL(t *testing.T) {
 				params:           &[]any{},
 			},
 			wantConditions: &[]string{
-				"key LIKE $1",
+				`"key" LIKE $1`,
 			},
 			wantParams: &[]any{
 				"%val%",
@@ -851,7 +854,7 @@ func Test_transformConditionSQL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			transformConditionSQL(tt.args.condition, tt.args.outputConditions, tt.args.params)
+			transformConditionSQL(tt.args.condition, tt.args.outputConditions, &sqlContext{dialect: SQLDialectPostgres{}, params: tt.args.params})
 			if !reflect.DeepEqual(tt.args.outputConditions, tt.wantConditions) {
 				t.Errorf("transformConditionSQL() conditions: got = %v, want %v", tt.args.outputConditions, tt.wantConditions)
 			}
@@ -861,3 +864,322 @@ func Test_transformConditionSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLOutputTransformer_TransformDialect(t1 *testing.T) {
+	input := contract.Filters{
+		Logic: contract.FilterLogicAnd,
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{
+				{Field: "users.name", Operator: contract.FilterOperatorBegins, Value: "Jo"},
+				{Field: "active", Operator: contract.FilterOperatorEqual, Value: true},
+				{Field: "tags", Operator: contract.FilterOperatorIn, Value: []string{"a", "b"}},
+				{Field: "age", Operator: contract.FilterOperatorGreaterThanOrEqualOrNil, Value: 18},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		transformer SQLOutputTransformer
+		want        SQLTuple
+		wantString  string
+	}{
+		{
+			name:        "default",
+			transformer: SQLOutputTransformer{},
+			want: SQLTuple{
+				Query:  `("users"."name" LIKE $1 AND "active" = $2 AND "tags" IN ($3, $4) AND ("age" >= $5 OR "age" IS NULL))`,
+				Params: []any{"Jo%", true, "a", "b", 18},
+			},
+			wantString: `("users"."name" LIKE 'Jo%' AND "active" = 'true' AND "tags" IN ('a', 'b') AND ("age" >= '18' OR "age" IS NULL))`,
+		},
+		{
+			name:        "postgres with case-insensitive like and inlined booleans",
+			transformer: SQLOutputTransformer{Dialect: SQLDialectPostgres{}, CaseInsensitiveLike: true, InlineBooleans: true},
+			want: SQLTuple{
+				Query:  `("users"."name" ILIKE $1 AND "active" = TRUE AND "tags" IN ($2, $3) AND ("age" >= $4 OR "age" IS NULL))`,
+				Params: []any{"Jo%", "a", "b", 18},
+			},
+			wantString: `("users"."name" ILIKE 'Jo%' AND "active" = TRUE AND "tags" IN ('a', 'b') AND ("age" >= '18' OR "age" IS NULL))`,
+		},
+		{
+			name:        "mysql",
+			transformer: SQLOutputTransformer{Dialect: SQLDialectMySQL{}, CaseInsensitiveLike: true, InlineBooleans: true},
+			want: SQLTuple{
+				Query:  "(`users`.`name` LIKE ? AND `active` = TRUE AND `tags` IN (?, ?) AND (`age` >= ? OR `age` IS NULL))",
+				Params: []any{"Jo%", "a", "b", 18},
+			},
+			wantString: "(`users`.`name` LIKE 'Jo%' AND `active` = TRUE AND `tags` IN ('a', 'b') AND (`age` >= '18' OR `age` IS NULL))",
+		},
+		{
+			name:        "sql server",
+			transformer: SQLOutputTransformer{Dialect: SQLDialectSQLServer{}, InlineBooleans: true},
+			want: SQLTuple{
+				Query:  `([users].[name] LIKE @p1 ESCAPE '\' AND [active] = 1 AND [tags] IN (@p2, @p3) AND ([age] >= @p4 OR [age] IS NULL))`,
+				Params: []any{"Jo%", "a", "b", 18},
+			},
+			wantString: `([users].[name] LIKE 'Jo%' ESCAPE '\' AND [active] = 1 AND [tags] IN ('a', 'b') AND ([age] >= '18' OR [age] IS NULL))`,
+		},
+		{
+			name:        "oracle",
+			transformer: SQLOutputTransformer{Dialect: SQLDialectOracle{}, CaseInsensitiveLike: true, InlineBooleans: true},
+			want: SQLTuple{
+				Query:  `(UPPER("users"."name") LIKE UPPER(:1) ESCAPE '\' AND "active" = 1 AND "tags" IN (:2, :3) AND ("age" >= :4 OR "age" IS NULL))`,
+				Params: []any{"Jo%", "a", "b", 18},
+			},
+			wantString: `(UPPER("users"."name") LIKE UPPER('Jo%') ESCAPE '\' AND "active" = 1 AND "tags" IN ('a', 'b') AND ("age" >= '18' OR "age" IS NULL))`,
+		},
+		{
+			name:        "sqlite",
+			transformer: SQLOutputTransformer{Dialect: SQLDialectSQLite{CaseSensitiveGlob: true}, CaseInsensitiveLike: true, InlineBooleans: true},
+			want: SQLTuple{
+				Query:  `("users"."name" LIKE ? ESCAPE '\' AND "active" = 1 AND "tags" IN (?, ?) AND ("age" >= ? OR "age" IS NULL))`,
+				Params: []any{"Jo%", "a", "b", 18},
+			},
+			wantString: `("users"."name" LIKE 'Jo%' ESCAPE '\' AND "active" = 1 AND "tags" IN ('a', 'b') AND ("age" >= '18' OR "age" IS NULL))`,
+		},
+		{
+			name:        "oracle with named placeholders",
+			transformer: SQLOutputTransformer{Dialect: SQLDialectOracle{}, ParamPrefix: "p", InlineBooleans: true},
+			want: SQLTuple{
+				Query:  `("users"."name" LIKE :p1 ESCAPE '\' AND "active" = 1 AND "tags" IN (:p2, :p3) AND ("age" >= :p4 OR "age" IS NULL))`,
+				Params: []any{"Jo%", "a", "b", 18},
+			},
+			wantString: `("users"."name" LIKE 'Jo%' ESCAPE '\' AND "active" = 1 AND "tags" IN ('a', 'b') AND ("age" >= '18' OR "age" IS NULL))`,
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			got, err := tt.transformer.Transform(input)
+			if err != nil {
+				t1.Fatalf("Transform() error = %v", err)
+			}
+			gotData, _ := got.GetData()
+			if !reflect.DeepEqual(gotData, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", gotData, tt.want)
+			}
+			gotString, _ := got.GetDataString()
+			if gotString != tt.wantString {
+				t1.Errorf("GetDataString() got = %v, want %v", gotString, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestSQLOutput_GetDataStringPlaceholdersInValues(t *testing.T) {
+	output := SQLOutput{dialect: SQLDialectMySQL{}}
+	_ = output.SetData(SQLTuple{Query: "(a = ? AND b = ?)", Params: []any{"x", "why?"}})
+	got, _ := output.GetDataString()
+	if want := "(a = 'x' AND b = 'why?')"; got != want {
+		t.Errorf("GetDataString() got = %v, want %v", got, want)
+	}
+	output = SQLOutput{}
+	params := []any{"$2"}
+	query := []string{"a1 = $1"}
+	for index := 2; index <= 10; index++ {
+		params = append(params, index)
+		query = append(query, fmt.Sprintf("a%d = $%d", index, index))
+	}
+	_ = output.SetData(SQLTuple{Query: strings.Join(query, " AND "), Params: params})
+	got, _ = output.GetDataString()
+	if want := "a1 = '$2' AND a2 = '2' AND a3 = '3' AND a4 = '4' AND a5 = '5' AND a6 = '6' AND a7 = '7' AND a8 = '8' AND a9 = '9' AND a10 = '10'"; got != want {
+		t.Errorf("GetDataString() got = %v, want %v", got, want)
+	}
+}
+
+func TestSQLOutputTransformer_TransformEscapedLike(t1 *testing.T) {
+	input := contract.Filters{
+		Logic: contract.FilterLogicOr,
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{
+				{Field: "discount", Operator: contract.FilterOperatorBegins, Value: "50%"},
+				{Field: "code", Operator: contract.FilterOperatorContains, Value: `a_b\c`},
+				{Field: "code", Operator: contract.FilterOperatorNotContains, Value: "[x]"},
+				{Field: "amount", Operator: contract.FilterOperatorEnds, Value: 10},
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		dialect SQLDialectInterface
+		want    SQLTuple
+	}{
+		{
+			name:    "postgres",
+			dialect: SQLDialectPostgres{},
+			want: SQLTuple{
+				Query:  `("discount" LIKE $1 OR "code" LIKE $2 OR "code" NOT LIKE $3 OR "amount" LIKE $4)`,
+				Params: []any{`50\%%`, `%a\_b\\c%`, "%[x]%", "%10"},
+			},
+		},
+		{
+			name:    "sqlite with glob",
+			dialect: SQLDialectSQLite{CaseSensitiveGlob: true},
+			want: SQLTuple{
+				Query:  `("discount" GLOB ? OR "code" GLOB ? OR "code" NOT GLOB ? OR "amount" GLOB ?)`,
+				Params: []any{"50%*", `*a_b\c*`, "*[[]x]*", "*10"},
+			},
+		},
+		{
+			name:    "sql server",
+			dialect: SQLDialectSQLServer{},
+			want: SQLTuple{
+				Query:  `([discount] LIKE @p1 ESCAPE '\' OR [code] LIKE @p2 ESCAPE '\' OR [code] NOT LIKE @p3 ESCAPE '\' OR [amount] LIKE @p4 ESCAPE '\')`,
+				Params: []any{`50\%%`, `%a\_b\\c%`, `%\[x]%`, "%10"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := SQLOutputTransformer{Dialect: tt.dialect}
+			got, err := t.Transform(input)
+			if err != nil {
+				t1.Fatalf("Transform() error = %v", err)
+			}
+			gotData, _ := got.GetData()
+			if !reflect.DeepEqual(gotData, tt.want) {
+				t1.Errorf("Transform() got = %v, want %v", gotData, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLOutputTransformer_TransformNamedParams(t1 *testing.T) {
+	input := contract.Filters{
+		Logic: contract.FilterLogicAnd,
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{
+				{Field: "name", Operator: contract.FilterOperatorEqual, Value: "Jo"},
+				{Field: "tags", Operator: contract.FilterOperatorNotIn, Value: []any{"a", "b"}},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		transformer     SQLOutputTransformer
+		wantQuery       string
+		wantString      string
+		wantNamedParams map[string]any
+		wantNamedArgs   []sql.NamedArg
+		wantNamedErr    bool
+	}{
+		{
+			name:         "offset",
+			transformer:  SQLOutputTransformer{ParamOffset: 9},
+			wantQuery:    `("name" = $10 AND "tags" NOT IN ($11, $12))`,
+			wantString:   `("name" = 'Jo' AND "tags" NOT IN ('a', 'b'))`,
+			wantNamedErr: true,
+		},
+		{
+			name:            "named params",
+			transformer:     SQLOutputTransformer{Dialect: SQLDialectMySQL{}, ParamPrefix: "filter_"},
+			wantQuery:       "(`name` = :filter_1 AND `tags` NOT IN (:filter_2, :filter_3))",
+			wantString:      "(`name` = 'Jo' AND `tags` NOT IN ('a', 'b'))",
+			wantNamedParams: map[string]any{"filter_1": "Jo", "filter_2": "a", "filter_3": "b"},
+			wantNamedArgs:   []sql.NamedArg{sql.Named("filter_1", "Jo"), sql.Named("filter_2", "a"), sql.Named("filter_3", "b")},
+		},
+		{
+			name:            "named params with offset",
+			transformer:     SQLOutputTransformer{Dialect: SQLDialectSQLServer{}, ParamPrefix: "p", ParamOffset: 2},
+			wantQuery:       "([name] = @p3 AND [tags] NOT IN (@p4, @p5))",
+			wantString:      "([name] = 'Jo' AND [tags] NOT IN ('a', 'b'))",
+			wantNamedParams: map[string]any{"p3": "Jo", "p4": "a", "p5": "b"},
+			wantNamedArgs:   []sql.NamedArg{sql.Named("p3", "Jo"), sql.Named("p4", "a"), sql.Named("p5", "b")},
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			got, err := tt.transformer.Transform(input)
+			if err != nil {
+				t1.Fatalf("Transform() error = %v", err)
+			}
+			gotData, _ := got.GetData()
+			if gotData.Query != tt.wantQuery {
+				t1.Errorf("Transform() got = %v, want %v", gotData.Query, tt.wantQuery)
+			}
+			gotString, _ := got.GetDataString()
+			if gotString != tt.wantString {
+				t1.Errorf("GetDataString() got = %v, want %v", gotString, tt.wantString)
+			}
+			gotNamedParams, namedErr := got.GetNamedParams()
+			if (namedErr != nil) != tt.wantNamedErr {
+				t1.Errorf("GetNamedParams() error = %v, wantErr %v", namedErr, tt.wantNamedErr)
+			}
+			if !reflect.DeepEqual(gotNamedParams, tt.wantNamedParams) {
+				t1.Errorf("GetNamedParams() got = %v, want %v", gotNamedParams, tt.wantNamedParams)
+			}
+			gotNamedArgs, namedErr := got.GetNamedArgs()
+			if (namedErr != nil) != tt.wantNamedErr {
+				t1.Errorf("GetNamedArgs() error = %v, wantErr %v", namedErr, tt.wantNamedErr)
+			}
+			if !reflect.DeepEqual(gotNamedArgs, tt.wantNamedArgs) {
+				t1.Errorf("GetNamedArgs() got = %v, want %v", gotNamedArgs, tt.wantNamedArgs)
+			}
+		})
+	}
+}
+
+func TestSQLOutputTransformer_TransformInvalidField(t1 *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		wantErr *contract.Error
+	}{
+		{
+			name:    "statement injection",
+			field:   "id; DROP TABLE x--",
+			wantErr: contract.NewError(contract.NonWriteableOutputData, `invalid field "id; DROP TABLE x--"`),
+		},
+		{
+			name:    "quote injection",
+			field:   `name" OR 1=1 OR "`,
+			wantErr: contract.NewError(contract.NonWriteableOutputData, `invalid field "name\" OR 1=1 OR \""`),
+		},
+		{
+			name:    "empty path segment",
+			field:   "users..name",
+			wantErr: contract.NewError(contract.NonWriteableOutputData, `invalid field "users..name"`),
+		},
+		{
+			name:    "leading digit",
+			field:   "1name",
+			wantErr: contract.NewError(contract.NonWriteableOutputData, `invalid field "1name"`),
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			t := SQLOutputTransformer{}
+			input := contract.Filters{
+				Logic: contract.FilterLogicAnd,
+				Conditions: contract.FilterConditions{
+					Conditions: []contract.FilterCondition{
+						{Field: "active", Operator: contract.FilterOperatorEqual, Value: true},
+						{Field: tt.field, Operator: contract.FilterOperatorEqual, Value: "val"},
+					},
+				},
+			}
+			got, err := t.Transform(input)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t1.Errorf("Transform() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != nil {
+				t1.Errorf("Transform() got = %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestSQLOutputTransformer_TransformUnsupportedOperator(t1 *testing.T) {
+	t := SQLOutputTransformer{}
+	input := contract.Filters{
+		Conditions: contract.FilterConditions{
+			Conditions: []contract.FilterCondition{
+				{Field: "key", Operator: "unknown", Value: "val"},
+			},
+		},
+	}
+	_, err := t.Transform(input)
+	wantErr := contract.NewError(contract.NonWriteableOutputData, "unsupported operator unknown")
+	if !reflect.DeepEqual(err, wantErr) {
+		t1.Errorf("Transform() error = %v, wantErr %v", err, wantErr)
+	}
+}
@@ -0,0 +1,232 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SQLDialectInterface controls the parts of the query that differ between databases
+type SQLDialectInterface interface {
+	// Placeholder returns the placeholder of the parameter at the given (1-based) position
+	Placeholder(position int) string
+	NamedPlaceholder(name string) string
+	// QuoteIdentifier quotes each part of a dotted path (e.g. `table.column`)
+	QuoteIdentifier(identifier string) string
+	// LikePattern escapes the wildcards in the value and adds the leading and/or trailing wildcard
+	LikePattern(value string, leadingWildcard bool, trailingWildcard bool, caseInsensitive bool) string
+	// Like matches the field against the pattern (created using LikePattern) in the placeholder
+	Like(field string, placeholder string, negated bool, caseInsensitive bool) string
+	BooleanLiteral(value bool) string
+}
+
+func quoteIdentifierParts(identifier string, opening string, closing string) string {
+	parts := strings.Split(identifier, ".")
+	for index, part := range parts {
+		parts[index] = opening + strings.ReplaceAll(part, closing, closing+closing) + closing
+	}
+	return strings.Join(parts, ".")
+}
+
+var likeValueEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func wrapPattern(value string, wildcard string, leadingWildcard bool, trailingWildcard bool) string {
+	if leadingWildcard {
+		value = wildcard + value
+	}
+	if trailingWildcard {
+		value += wildcard
+	}
+	return value
+}
+
+func formatLike(field string, operator string, placeholder string, negated bool) string {
+	if negated {
+		operator = "NOT " + operator
+	}
+	return fmt.Sprintf("%s %s %s", field, operator, placeholder)
+}
+
+// SQLDialectPostgres is the default dialect
+type SQLDialectPostgres struct {
+}
+
+func (d SQLDialectPostgres) Placeholder(position int) string {
+	return fmt.Sprintf("$%d", position)
+}
+
+// NamedPlaceholder uses the syntax of pgx.NamedArgs
+func (d SQLDialectPostgres) NamedPlaceholder(name string) string {
+	return "@" + name
+}
+
+func (d SQLDialectPostgres) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierParts(identifier, `"`, `"`)
+}
+
+func (d SQLDialectPostgres) LikePattern(value string, leadingWildcard bool, trailingWildcard bool, caseInsensitive bool) string {
+	return wrapPattern(likeValueEscaper.Replace(value), "%", leadingWildcard, trailingWildcard)
+}
+
+// Like doesn't need the ESCAPE clause as backslash is the default escape character
+func (d SQLDialectPostgres) Like(field string, placeholder string, negated bool, caseInsensitive bool) string {
+	if caseInsensitive {
+		return formatLike(field, "ILIKE", placeholder, negated)
+	}
+	return formatLike(field, "LIKE", placeholder, negated)
+}
+
+func (d SQLDialectPostgres) BooleanLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// SQLDialectMySQL is meant for MySQL and MariaDB
+type SQLDialectMySQL struct {
+}
+
+func (d SQLDialectMySQL) Placeholder(position int) string {
+	return "?"
+}
+
+// NamedPlaceholder uses the syntax of sqlx
+func (d SQLDialectMySQL) NamedPlaceholder(name string) string {
+	return ":" + name
+}
+
+func (d SQLDialectMySQL) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierParts(identifier, "`", "`")
+}
+
+func (d SQLDialectMySQL) LikePattern(value string, leadingWildcard bool, trailingWildcard bool, caseInsensitive bool) string {
+	return wrapPattern(likeValueEscaper.Replace(value), "%", leadingWildcard, trailingWildcard)
+}
+
+// Like relies on the collation of the column, LIKE is case-insensitive with the default (`_ci`) collations;
+// backslash is the default escape character (unless NO_BACKSLASH_ESCAPES is enabled)
+func (d SQLDialectMySQL) Like(field string, placeholder string, negated bool, caseInsensitive bool) string {
+	return formatLike(field, "LIKE", placeholder, negated)
+}
+
+func (d SQLDialectMySQL) BooleanLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// SQLDialectSQLServer is meant for Microsoft SQL Server (the placeholders are supported by go-mssqldb)
+type SQLDialectSQLServer struct {
+}
+
+func (d SQLDialectSQLServer) Placeholder(position int) string {
+	return fmt.Sprintf("@p%d", position)
+}
+
+func (d SQLDialectSQLServer) NamedPlaceholder(name string) string {
+	return "@" + name
+}
+
+func (d SQLDialectSQLServer) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierParts(identifier, "[", "]")
+}
+
+// LikePattern escapes `[` as well as it starts a character range in SQL Server
+func (d SQLDialectSQLServer) LikePattern(value string, leadingWildcard bool, trailingWildcard bool, caseInsensitive bool) string {
+	return wrapPattern(strings.ReplaceAll(likeValueEscaper.Replace(value), "[", `\[`), "%", leadingWildcard, trailingWildcard)
+}
+
+// Like relies on the collation of the column, LIKE is case-insensitive with the default (`_CI_`) collations
+func (d SQLDialectSQLServer) Like(field string, placeholder string, negated bool, caseInsensitive bool) string {
+	return formatLike(field, "LIKE", placeholder, negated) + ` ESCAPE '\'`
+}
+
+func (d SQLDialectSQLServer) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// SQLDialectOracle is meant for Oracle Database
+type SQLDialectOracle struct {
+}
+
+func (d SQLDialectOracle) Placeholder(position int) string {
+	return fmt.Sprintf(":%d", position)
+}
+
+func (d SQLDialectOracle) NamedPlaceholder(name string) string {
+	return ":" + name
+}
+
+func (d SQLDialectOracle) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierParts(identifier, `"`, `"`)
+}
+
+func (d SQLDialectOracle) LikePattern(value string, leadingWildcard bool, trailingWildcard bool, caseInsensitive bool) string {
+	return wrapPattern(likeValueEscaper.Replace(value), "%", leadingWildcard, trailingWildcard)
+}
+
+// Like compares upper-cased values to make the match case-insensitive
+func (d SQLDialectOracle) Like(field string, placeholder string, negated bool, caseInsensitive bool) string {
+	if caseInsensitive {
+		field, placeholder = fmt.Sprintf("UPPER(%s)", field), fmt.Sprintf("UPPER(%s)", placeholder)
+	}
+	return formatLike(field, "LIKE", placeholder, negated) + ` ESCAPE '\'`
+}
+
+// BooleanLiteral uses numbers as there is no boolean type in SQL before Oracle 23ai
+func (d SQLDialectOracle) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+var globValueEscaper = strings.NewReplacer(`*`, `[*]`, `?`, `[?]`, `[`, `[[]`)
+
+// SQLDialectSQLite is meant for SQLite, LIKE is case-insensitive for ASCII characters
+type SQLDialectSQLite struct {
+	// CaseSensitiveGlob uses GLOB instead of LIKE unless the match is requested to be case-insensitive
+	CaseSensitiveGlob bool
+}
+
+func (d SQLDialectSQLite) Placeholder(position int) string {
+	return "?"
+}
+
+func (d SQLDialectSQLite) NamedPlaceholder(name string) string {
+	return ":" + name
+}
+
+func (d SQLDialectSQLite) QuoteIdentifier(identifier string) string {
+	return quoteIdentifierParts(identifier, `"`, `"`)
+}
+
+func (d SQLDialectSQLite) useGlob(caseInsensitive bool) bool {
+	return d.CaseSensitiveGlob && !caseInsensitive
+}
+
+func (d SQLDialectSQLite) LikePattern(value string, leadingWildcard bool, trailingWildcard bool, caseInsensitive bool) string {
+	if d.useGlob(caseInsensitive) {
+		return wrapPattern(globValueEscaper.Replace(value), "*", leadingWildcard, trailingWildcard)
+	}
+	return wrapPattern(likeValueEscaper.Replace(value), "%", leadingWildcard, trailingWildcard)
+}
+
+// Like needs the ESCAPE clause as there is no default escape character, GLOB escapes the wildcards using brackets
+func (d SQLDialectSQLite) Like(field string, placeholder string, negated bool, caseInsensitive bool) string {
+	if d.useGlob(caseInsensitive) {
+		return formatLike(field, "GLOB", placeholder, negated)
+	}
+	return formatLike(field, "LIKE", placeholder, negated) + ` ESCAPE '\'`
+}
+
+func (d SQLDialectSQLite) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,131 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestSQLDialects(t *testing.T) {
+	tests := []struct {
+		name                 string
+		dialect              SQLDialectInterface
+		wantPlaceholder      string
+		wantNamedPlaceholder string
+		wantIdentifier       string
+		wantPattern          string
+		wantLike             string
+		wantNotLike          string
+		wantCaseInsensitive  string
+		wantTrue             string
+		wantFalse            string
+	}{
+		{
+			name:                 "postgres",
+			dialect:              SQLDialectPostgres{},
+			wantPlaceholder:      "$12",
+			wantNamedPlaceholder: "@p12",
+			wantIdentifier:       `"users"."Full ""Name"""`,
+			wantPattern:          `%50\% [a]\_b\\c%`,
+			wantLike:             "name LIKE $1",
+			wantNotLike:          "name NOT LIKE $1",
+			wantCaseInsensitive:  "name NOT ILIKE $1",
+			wantTrue:             "TRUE",
+			wantFalse:            "FALSE",
+		},
+		{
+			name:                 "mysql",
+			dialect:              SQLDialectMySQL{},
+			wantPlaceholder:      "?",
+			wantNamedPlaceholder: ":p12",
+			wantIdentifier:       "`users`.`Full \"Name\"`",
+			wantPattern:          `%50\% [a]\_b\\c%`,
+			wantLike:             "name LIKE $1",
+			wantNotLike:          "name NOT LIKE $1",
+			wantCaseInsensitive:  "name NOT LIKE $1",
+			wantTrue:             "TRUE",
+			wantFalse:            "FALSE",
+		},
+		{
+			name:                 "sql server",
+			dialect:              SQLDialectSQLServer{},
+			wantPlaceholder:      "@p12",
+			wantNamedPlaceholder: "@p12",
+			wantIdentifier:       `[users].[Full "Name"]`,
+			wantPattern:          `%50\% \[a]\_b\\c%`,
+			wantLike:             `name LIKE $1 ESCAPE '\'`,
+			wantNotLike:          `name NOT LIKE $1 ESCAPE '\'`,
+			wantCaseInsensitive:  `name NOT LIKE $1 ESCAPE '\'`,
+			wantTrue:             "1",
+			wantFalse:            "0",
+		},
+		{
+			name:                 "oracle",
+			dialect:              SQLDialectOracle{},
+			wantPlaceholder:      ":12",
+			wantNamedPlaceholder: ":p12",
+			wantIdentifier:       `"users"."Full ""Name"""`,
+			wantPattern:          `%50\% [a]\_b\\c%`,
+			wantLike:             `name LIKE $1 ESCAPE '\'`,
+			wantNotLike:          `name NOT LIKE $1 ESCAPE '\'`,
+			wantCaseInsensitive:  `UPPER(name) NOT LIKE UPPER($1) ESCAPE '\'`,
+			wantTrue:             "1",
+			wantFalse:            "0",
+		},
+		{
+			name:                 "sqlite",
+			dialect:              SQLDialectSQLite{},
+			wantPlaceholder:      "?",
+			wantNamedPlaceholder: ":p12",
+			wantIdentifier:       `"users"."Full ""Name"""`,
+			wantPattern:          `%50\% [a]\_b\\c%`,
+			wantLike:             `name LIKE $1 ESCAPE '\'`,
+			wantNotLike:          `name NOT LIKE $1 ESCAPE '\'`,
+			wantCaseInsensitive:  `name NOT LIKE $1 ESCAPE '\'`,
+			wantTrue:             "1",
+			wantFalse:            "0",
+		},
+		{
+			name:                 "sqlite with glob",
+			dialect:              SQLDialectSQLite{CaseSensitiveGlob: true},
+			wantPlaceholder:      "?",
+			wantNamedPlaceholder: ":p12",
+			wantIdentifier:       `"users"."Full ""Name"""`,
+			wantPattern:          `*50% [[]a]_b\c*`,
+			wantLike:             "name GLOB $1",
+			wantNotLike:          "name NOT GLOB $1",
+			wantCaseInsensitive:  `name NOT LIKE $1 ESCAPE '\'`,
+			wantTrue:             "1",
+			wantFalse:            "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.Placeholder(12); got != tt.wantPlaceholder {
+				t.Errorf("Placeholder() got = %v, want %v", got, tt.wantPlaceholder)
+			}
+			if got := tt.dialect.NamedPlaceholder("p12"); got != tt.wantNamedPlaceholder {
+				t.Errorf("NamedPlaceholder() got = %v, want %v", got, tt.wantNamedPlaceholder)
+			}
+			if got := tt.dialect.QuoteIdentifier(`users.Full "Name"`); got != tt.wantIdentifier {
+				t.Errorf("QuoteIdentifier() got = %v, want %v", got, tt.wantIdentifier)
+			}
+			if got := tt.dialect.LikePattern(`50% [a]_b\c`, true, true, false); got != tt.wantPattern {
+				t.Errorf("LikePattern() got = %v, want %v", got, tt.wantPattern)
+			}
+			if got := tt.dialect.Like("name", "$1", false, false); got != tt.wantLike {
+				t.Errorf("Like() got = %v, want %v", got, tt.wantLike)
+			}
+			if got := tt.dialect.Like("name", "$1", true, false); got != tt.wantNotLike {
+				t.Errorf("Like() negated got = %v, want %v", got, tt.wantNotLike)
+			}
+			if got := tt.dialect.Like("name", "$1", true, true); got != tt.wantCaseInsensitive {
+				t.Errorf("Like() case-insensitive got = %v, want %v", got, tt.wantCaseInsensitive)
+			}
+			if got := tt.dialect.BooleanLiteral(true); got != tt.wantTrue {
+				t.Errorf("BooleanLiteral() got = %v, want %v", got, tt.wantTrue)
+			}
+			if got := tt.dialect.BooleanLiteral(false); got != tt.wantFalse {
+				t.Errorf("BooleanLiteral() got = %v, want %v", got, tt.wantFalse)
+			}
+		})
+	}
+}